@@ -1,112 +1,71 @@
 package gografana
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
 )
 
 const grafanaOK string = "success"
 
+// GrafanaClient_5_0 适用于Grafana 5.x/6.x，同一个Client可以被多个goroutine并发使用。
 type GrafanaClient_5_0 struct {
-	basicAddress  string
-	client        *http.Client
-	authenticator Authenticator
-	httpProxy     string
+	*baseClient
 }
 
-type NewDashboardError struct {
-	Err    error
-	Status string
+// NewClient 创建一个适用于Grafana 5.x/6.x的Client，默认会校验Grafana服务端的TLS证书，
+// 授权方式、代理、TLS配置等均通过opts设置，例如:
+//
+//	client, err := gografana.NewClient("https://grafana.example.com",
+//		gografana.WithAuthenticator(gografana.NewAPIKeyAuthenticator(key)),
+//		gografana.WithCAFile("/etc/ssl/grafana-ca.pem"),
+//		gografana.WithTimeout(30*time.Second))
+func NewClient(apiAddress string, opts ...Option) (*GrafanaClient_5_0, error) {
+	bc, err := newBaseClient(apiAddress, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GrafanaClient_5_0{baseClient: bc}, nil
 }
 
-func (e NewDashboardError) Error() string {
-	return fmt.Sprintf("Internal Error: %s, Status: %s", e.Err.Error(), e.Status)
+func (gc *GrafanaClient_5_0) GetAllDashboards() ([]SearchHit, error) {
+	return gc.GetAllDashboardsCtx(context.Background())
 }
 
-func (gc *GrafanaClient_5_0) initClient() error {
-	if gc.client != nil {
-		return nil
-	}
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-	if gc.httpProxy != "" {
-		proxyUrl, err := url.Parse(gc.httpProxy)
-		if err != nil {
-			return err
-		}
-		tr.Proxy = http.ProxyURL(proxyUrl)
-	}
-	gc.client = &http.Client{Transport: tr}
-	return nil
+func (gc *GrafanaClient_5_0) GetAllDashboardsCtx(ctx context.Context) ([]SearchHit, error) {
+	return gc.search(ctx, SearchQuery{Type: SearchTypeDashboard}, "GetAllDashboards(api/search?type=dash-db)")
 }
 
-func (gc *GrafanaClient_5_0) GetAllDashboards() ([]Board, error) {
-	urlPath := fmt.Sprintf("%s/api/search?type=dash-db", gc.basicAddress)
-	req, err := http.NewRequest("GET", urlPath, nil)
-	if err != nil {
-		return nil, err
-	}
-	bodyData, err := gc.getHTTPResponse(req, "GetAllDashboards(api/search?type=dash-db)")
-	if err != nil {
-		return nil, err
-	}
-	var boards []Board
-	err = json.Unmarshal(bodyData, &boards)
-	if err != nil {
-		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetAllDashboards(api/search?type=dash-db), error: %s", err.Error())
-	}
-	return boards, nil
+func (gc *GrafanaClient_5_0) GetDashboardsByTitleAndFolderId(title string, folderId int) ([]SearchHit, error) {
+	return gc.GetDashboardsByTitleAndFolderIdCtx(context.Background(), title, folderId)
 }
 
-func (gc *GrafanaClient_5_0) GetDashboardsByTitleAndFolderId(title string, folderId int) ([]Board, error) {
-	urlPath := fmt.Sprintf("%s/api/search?query=%s&folderIds=%s", gc.basicAddress, title, strconv.Itoa(folderId))
-	req, err := http.NewRequest("GET", urlPath, nil)
-	if err != nil {
-		return nil, err
-	}
-	bodyData, err := gc.getHTTPResponse(req, "GetDashboardsByTitleAndFolderId(api/search?query=&folderIds=)")
-	if err != nil {
-		return nil, err
-	}
-	var boards []Board
-	err = json.Unmarshal(bodyData, &boards)
-	if err != nil {
-		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetDashboardsByTitleAndFolderId(api/search?query=&folerIds=), error: %s", err.Error())
-	}
-	return boards, nil
+func (gc *GrafanaClient_5_0) GetDashboardsByTitleAndFolderIdCtx(ctx context.Context, title string, folderId int) ([]SearchHit, error) {
+	return gc.search(ctx, SearchQuery{Query: title, FolderIDs: []int{folderId}}, "GetDashboardsByTitleAndFolderId(api/search?query=&folderIds=)")
 }
 
-func (gc *GrafanaClient_5_0) GetDashboardsByFolderId(folderId int) ([]Board, error) {
-	urlPath := fmt.Sprintf("%s/api/search?folderIds=%d", gc.basicAddress, folderId)
-	req, err := http.NewRequest("GET", urlPath, nil)
-	if err != nil {
-		return nil, err
-	}
-	bodyData, err := gc.getHTTPResponse(req, "GetAllDashboards(api/search?type=folderIds)")
-	if err != nil {
-		return nil, err
-	}
-	var boards []Board
-	err = json.Unmarshal(bodyData, &boards)
-	if err != nil {
-		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetAllDashboards(api/search?type=folderIds), error: %s", err.Error())
-	}
-	return boards, nil
+func (gc *GrafanaClient_5_0) GetDashboardsByFolderId(folderId int) ([]SearchHit, error) {
+	return gc.GetDashboardsByFolderIdCtx(context.Background(), folderId)
 }
 
-func (gc *GrafanaClient_5_0) IsBoardExists(title string) (bool, *Board, error) {
-	boards, err := gc.GetAllDashboards()
+func (gc *GrafanaClient_5_0) GetDashboardsByFolderIdCtx(ctx context.Context, folderId int) ([]SearchHit, error) {
+	return gc.search(ctx, SearchQuery{FolderIDs: []int{folderId}}, "GetDashboardsByFolderId(api/search?folderIds=)")
+}
+
+// IsBoardExists 按照标题判断Dashboard是否存在，存在时返回对应的搜索结果，完整的Dashboard可以通过GetDashboardForHit获取
+func (gc *GrafanaClient_5_0) IsBoardExists(title string) (bool, *SearchHit, error) {
+	return gc.IsBoardExistsCtx(context.Background(), title)
+}
+
+func (gc *GrafanaClient_5_0) IsBoardExistsCtx(ctx context.Context, title string) (bool, *SearchHit, error) {
+	hits, err := gc.GetAllDashboardsCtx(ctx)
 	if err != nil {
 		return false, nil, err
 	}
-	for _, v := range boards {
+	for _, v := range hits {
 		if v.Title == title {
 			return true, &v, nil
 		}
@@ -115,20 +74,55 @@ func (gc *GrafanaClient_5_0) IsBoardExists(title string) (bool, *Board, error) {
 }
 
 func (gc *GrafanaClient_5_0) NewDashboard(board *Board, folderId uint, overwrite bool) (*Board, error) {
+	return gc.NewDashboardCtx(context.Background(), board, folderId, overwrite)
+}
+
+func (gc *GrafanaClient_5_0) NewDashboardCtx(ctx context.Context, board *Board, folderId uint, overwrite bool) (*Board, error) {
+	return gc.SaveDashboardCtx(ctx, board, SaveDashboardOptions{FolderID: folderId, Overwrite: overwrite})
+}
+
+// SaveDashboard 按照opts保存board。设置了ExpectedVersion时，只有Grafana中当前的版本与之相同才会保存成功，
+// 否则返回的错误满足IsVersionMismatch，从而避免覆盖其他人同时做出的修改。
+func (gc *GrafanaClient_5_0) SaveDashboard(board *Board, opts SaveDashboardOptions) (*Board, error) {
+	return gc.SaveDashboardCtx(context.Background(), board, opts)
+}
+
+func (gc *GrafanaClient_5_0) SaveDashboardCtx(ctx context.Context, board *Board, opts SaveDashboardOptions) (*Board, error) {
+	if opts.ExpectedVersion > 0 && opts.Overwrite {
+		return board, errors.New("ExpectedVersion cannot be combined with Overwrite, Grafana skips the version check when overwriting")
+	}
+	return gc.postDashboard(ctx, board, opts)
+}
+
+//postDashboard 按照opts保存board，ExpectedVersion只写入请求体中的副本，保存失败时board.Version保持不变
+func (gc *GrafanaClient_5_0) postDashboard(ctx context.Context, board *Board, opts SaveDashboardOptions) (*Board, error) {
 	if board.Timezone == "" {
 		board.Timezone = "browser"
 	}
-	bodyReq := CreateDashboardRequest{Board: *board, Overwrite: overwrite, FolderId: folderId}
+	bodyReq := CreateDashboardRequest{
+		Board:     *board,
+		FolderId:  opts.FolderID,
+		FolderUid: opts.FolderUID,
+		Overwrite: opts.Overwrite,
+		Message:   opts.Message,
+	}
+	if opts.ExpectedVersion > 0 {
+		bodyReq.Board.Version = opts.ExpectedVersion
+	}
 	bodyStr, err := json.Marshal(bodyReq)
 	if err != nil {
 		return board, err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/dashboards/db", gc.basicAddress), strings.NewReader(string(bodyStr)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/dashboards/db", gc.basicAddress), strings.NewReader(string(bodyStr)))
 	if err != nil {
 		return board, err
 	}
 	rspBody, err := gc.getHTTPResponse(req, "NewDashboard(api/dashboards/db)")
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+			return board, &NewDashboardError{Err: err, Status: apiErr.Status}
+		}
 		return board, err
 	}
 	var rsp CreateDashboardResponse
@@ -148,6 +142,10 @@ func (gc *GrafanaClient_5_0) NewDashboard(board *Board, folderId uint, overwrite
 }
 
 func (gc *GrafanaClient_5_0) CreateAPIKey(name string, role string, secondsToLive int) (string, error) {
+	return gc.CreateAPIKeyCtx(context.Background(), name, role, secondsToLive)
+}
+
+func (gc *GrafanaClient_5_0) CreateAPIKeyCtx(ctx context.Context, name string, role string, secondsToLive int) (string, error) {
 	body := map[string]interface{}{
 		"name":          name,
 		"role":          role,
@@ -158,7 +156,10 @@ func (gc *GrafanaClient_5_0) CreateAPIKey(name string, role string, secondsToLiv
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/auth/keys", gc.basicAddress), strings.NewReader(string(b)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/auth/keys", gc.basicAddress), strings.NewReader(string(b)))
+	if err != nil {
+		return "", err
+	}
 	rspBody, err := gc.getHTTPResponse(req, "CreateAPIKey(api/auth/keys)")
 	if err != nil {
 		return "", err
@@ -174,7 +175,14 @@ func (gc *GrafanaClient_5_0) CreateAPIKey(name string, role string, secondsToLiv
 }
 
 func (gc *GrafanaClient_5_0) FindAllAPIKeys() ([]APIKey, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/auth/keys", gc.basicAddress), nil)
+	return gc.FindAllAPIKeysCtx(context.Background())
+}
+
+func (gc *GrafanaClient_5_0) FindAllAPIKeysCtx(ctx context.Context) ([]APIKey, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/auth/keys", gc.basicAddress), nil)
+	if err != nil {
+		return nil, err
+	}
 	rspBody, err := gc.getHTTPResponse(req, "GetAllAPIKeys(api/auth/keys)")
 	if err != nil {
 		return nil, err
@@ -188,7 +196,11 @@ func (gc *GrafanaClient_5_0) FindAllAPIKeys() ([]APIKey, error) {
 }
 
 func (gc *GrafanaClient_5_0) DeleteAPIKey(id int) (bool, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/auth/keys/%d", gc.basicAddress, id), nil)
+	return gc.DeleteAPIKeyCtx(context.Background(), id)
+}
+
+func (gc *GrafanaClient_5_0) DeleteAPIKeyCtx(ctx context.Context, id int) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/auth/keys/%d", gc.basicAddress, id), nil)
 	if err != nil {
 		return false, err
 	}
@@ -206,7 +218,11 @@ func (gc *GrafanaClient_5_0) DeleteAPIKey(id int) (bool, error) {
 // 403 – Access denied
 // 404 – Not found
 func (gc *GrafanaClient_5_0) DeleteDashboard(uid string) (bool, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api/dashboards/uid/%s", gc.basicAddress, uid), nil)
+	return gc.DeleteDashboardCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_5_0) DeleteDashboardCtx(ctx context.Context, uid string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/dashboards/uid/%s", gc.basicAddress, uid), nil)
 	if err != nil {
 		return false, err
 	}
@@ -224,7 +240,11 @@ func (gc *GrafanaClient_5_0) DeleteDashboard(uid string) (bool, error) {
 // 403 – Access denied
 // 404 – Not found
 func (gc *GrafanaClient_5_0) GetDashboardDetails(uid string) (*Board, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/dashboards/uid/%s", gc.basicAddress, uid), nil)
+	return gc.GetDashboardDetailsCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_5_0) GetDashboardDetailsCtx(ctx context.Context, uid string) (*Board, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/dashboards/uid/%s", gc.basicAddress, uid), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -241,95 +261,34 @@ func (gc *GrafanaClient_5_0) GetDashboardDetails(uid string) (*Board, error) {
 }
 
 func (gc *GrafanaClient_5_0) EnsureFolderExists(folderId int, uid, title string) (int, bool, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/folders/id/%d", gc.basicAddress, folderId), nil)
-	if err != nil {
-		return -1, false, err
-	}
-	_, statusCode, err := gc.getHTTPResponseWithStatusCode(req, "GetFolderById(/api/folders/id/[FOLDER-ID])")
-	if err != nil {
-		return -1, false, err
-	}
+	return gc.EnsureFolderExistsCtx(context.Background(), folderId, uid, title)
+}
+
+func (gc *GrafanaClient_5_0) EnsureFolderExistsCtx(ctx context.Context, folderId int, uid, title string) (int, bool, error) {
+	folder, err := gc.GetFolderByIDCtx(ctx, folderId)
 	//folder existed.
-	if statusCode == 200 {
-		return -1, false, nil
-	}
-	//try to create a new folder.
-	bodyReq := CreateFolderRequest{UID: uid, Title: title}
-	bodyStr, err := json.Marshal(bodyReq)
-	if err != nil {
-		return -1, false, err
+	if err == nil {
+		return folder.ID, false, nil
 	}
-	var bodyData []byte
-	req, err = http.NewRequest("POST", fmt.Sprintf("%s/api/folders", gc.basicAddress), strings.NewReader(string(bodyStr)))
-	if err != nil {
+	if !IsNotFound(err) {
 		return -1, false, err
 	}
-	bodyData, err = gc.getHTTPResponse(req, "CreateFolder(/api/folders)")
+	//try to create a new folder.
+	folder = &Folder{UID: uid, Title: title}
+	err = gc.CreateFolderCtx(ctx, folder)
 	if err != nil {
 		return -1, false, err
 	}
-	var rsp CreateFolderResponse
-	err = json.Unmarshal(bodyData, &rsp)
-	if err != nil {
-		return -1, false, fmt.Errorf("Unmarshal response body failed while calling to API CreateFolder(/api/folders), error: %s", err.Error())
-	}
-	return rsp.ID, true, nil
-}
-
-func (gc *GrafanaClient_5_0) getHTTPResponse(req *http.Request, flag string) ([]byte, error) {
-	err := gc.initClient()
-	if err != nil {
-		return nil, err
-	}
-
-	//加入统一授权
-	gc.authenticator.SetAuthentication(req)
-	req.Header.Add("Content-Type", "application/json")
-	rsp, err := gc.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-	bodyData, err := ioutil.ReadAll(rsp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Read response body failed while calling to API %s, error: %s", flag, err.Error())
-	}
-	if rsp.StatusCode != 200 {
-		return nil, fmt.Errorf("Remote API returned Non 200/OK status code in the %s response(%d), body: %s", flag, rsp.StatusCode, string(bodyData))
-	}
-	return bodyData, nil
+	return folder.ID, true, nil
 }
 
-func (gc *GrafanaClient_5_0) getHTTPResponseWithStatusCode(req *http.Request, flag string) ([]byte, int, error) {
-	err := gc.initClient()
-	if err != nil {
-		return nil, -1, err
-	}
-
-	//加入统一授权
-	gc.authenticator.SetAuthentication(req)
-	req.Header.Add("Content-Type", "application/json")
-	rsp, err := gc.client.Do(req)
-	if err != nil {
-		return nil, -1, err
-	}
-	defer rsp.Body.Close()
-	bodyData, err := ioutil.ReadAll(rsp.Body)
-	if err != nil {
-		return nil, -1, fmt.Errorf("Read response body failed while calling to API %s, error: %s", flag, err.Error())
-	}
-	if rsp.StatusCode == 404 {
-		return nil, 404, nil
-	}
-	if rsp.StatusCode != 200 {
-		return nil, rsp.StatusCode, fmt.Errorf("Remote API returned Non 200/OK status code in the %s response(%d), body: %s", flag, rsp.StatusCode, string(bodyData))
-	}
-	return bodyData, rsp.StatusCode, nil
+func (gc *GrafanaClient_5_0) GetAllDataSources() ([]*DataSource, error) {
+	return gc.GetAllDataSourcesCtx(context.Background())
 }
 
-func (gc *GrafanaClient_5_0) GetAllDataSources() ([]*DataSource, error) {
+func (gc *GrafanaClient_5_0) GetAllDataSourcesCtx(ctx context.Context) ([]*DataSource, error) {
 	urlPath := fmt.Sprintf("%s/api/datasources", gc.basicAddress)
-	req, err := http.NewRequest("GET", urlPath, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -346,49 +305,58 @@ func (gc *GrafanaClient_5_0) GetAllDataSources() ([]*DataSource, error) {
 }
 
 func (gc *GrafanaClient_5_0) GetDashSourceById(id int) (*DataSource, error) {
+	return gc.GetDashSourceByIdCtx(context.Background(), id)
+}
+
+func (gc *GrafanaClient_5_0) GetDashSourceByIdCtx(ctx context.Context, id int) (*DataSource, error) {
 	urlPath := fmt.Sprintf("%s/api/datasources/%d", gc.basicAddress, id)
-	req, err := http.NewRequest("GET", urlPath, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
 	if err != nil {
 		return nil, err
 	}
-	bodyData, err := gc.getHTTPResponse(req, "GetDashSourceById(api/datasources)")
+	bodyData, err := gc.getHTTPResponse(req, "GetDashSourceById(api/datasources/[ID])")
 	if err != nil {
 		return nil, err
 	}
 	var ds DataSource
 	err = json.Unmarshal(bodyData, &ds)
 	if err != nil {
-		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetDashSourceById(api/datasources), error: %s", err.Error())
+		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetDashSourceById(api/datasources/[ID]), error: %s", err.Error())
 	}
 	return &ds, nil
 }
 
 func (gc *GrafanaClient_5_0) DeleteDashSource(id int) error {
+	return gc.DeleteDashSourceCtx(context.Background(), id)
+}
+
+func (gc *GrafanaClient_5_0) DeleteDashSourceCtx(ctx context.Context, id int) error {
 	urlPath := fmt.Sprintf("%s/api/datasources/%d", gc.basicAddress, id)
-	req, err := http.NewRequest("DELETE", urlPath, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", urlPath, nil)
 	if err != nil {
 		return err
 	}
-	_, err = gc.getHTTPResponse(req, "DeleteDashSource(api/datasources)")
+	_, err = gc.getHTTPResponse(req, "DeleteDashSource(api/datasources/[ID])")
 	return err
 }
 
 func (gc *GrafanaClient_5_0) CreateDashSource(ds *DataSource) error {
+	return gc.CreateDashSourceCtx(context.Background(), ds)
+}
+
+func (gc *GrafanaClient_5_0) CreateDashSourceCtx(ctx context.Context, ds *DataSource) error {
 	bodyStr, err := json.Marshal(ds)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/datasources", gc.basicAddress), strings.NewReader(string(bodyStr)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/datasources", gc.basicAddress), strings.NewReader(string(bodyStr)))
 	if err != nil {
 		return err
 	}
-	rspBody, statusCode, err := gc.getHTTPResponseWithStatusCode(req, "CreateDashSource(api/datasources)")
+	rspBody, err := gc.getHTTPResponse(req, "CreateDashSource(api/datasources)")
 	if err != nil {
 		return err
 	}
-	if statusCode != 200 {
-		return fmt.Errorf("HTTP Response != 200 while calling to API CreateDashSource(api/datasources), error: %s", err.Error())
-	}
 	var rsp CreateDataSourceResponse
 	err = json.Unmarshal(rspBody, &rsp)
 	if err != nil {
@@ -399,8 +367,12 @@ func (gc *GrafanaClient_5_0) CreateDashSource(ds *DataSource) error {
 }
 
 func (gc *GrafanaClient_5_0) GetAllFolders() ([]Folder, error) {
+	return gc.GetAllFoldersCtx(context.Background())
+}
+
+func (gc *GrafanaClient_5_0) GetAllFoldersCtx(ctx context.Context) ([]Folder, error) {
 	urlPath := fmt.Sprintf("%s/api/folders?limit=10000", gc.basicAddress)
-	req, err := http.NewRequest("GET", urlPath, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -417,8 +389,12 @@ func (gc *GrafanaClient_5_0) GetAllFolders() ([]Folder, error) {
 }
 
 func (gc *GrafanaClient_5_0) GetAllNotificationChannels() ([]NotificationChannel, error) {
+	return gc.GetAllNotificationChannelsCtx(context.Background())
+}
+
+func (gc *GrafanaClient_5_0) GetAllNotificationChannelsCtx(ctx context.Context) ([]NotificationChannel, error) {
 	urlPath := fmt.Sprintf("%s/api/alert-notifications", gc.basicAddress)
-	req, err := http.NewRequest("GET", urlPath, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -435,21 +411,22 @@ func (gc *GrafanaClient_5_0) GetAllNotificationChannels() ([]NotificationChannel
 }
 
 func (gc *GrafanaClient_5_0) CreateNotificationChannel(nc *NotificationChannel) error {
+	return gc.CreateNotificationChannelCtx(context.Background(), nc)
+}
+
+func (gc *GrafanaClient_5_0) CreateNotificationChannelCtx(ctx context.Context, nc *NotificationChannel) error {
 	bodyStr, err := json.Marshal(nc)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/alert-notifications", gc.basicAddress), strings.NewReader(string(bodyStr)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/alert-notifications", gc.basicAddress), strings.NewReader(string(bodyStr)))
 	if err != nil {
 		return err
 	}
-	rspBody, statusCode, err := gc.getHTTPResponseWithStatusCode(req, "CreateNotificationChannel(api/alert-notifications)")
+	rspBody, err := gc.getHTTPResponse(req, "CreateNotificationChannel(api/alert-notifications)")
 	if err != nil {
 		return err
 	}
-	if statusCode != 200 {
-		return fmt.Errorf("HTTP Response != 200 while calling to API CreateNotificationChannel(api/alert-notifications), error: %s", err.Error())
-	}
 	var rsp CreateNotificationChannelResponse
 	err = json.Unmarshal(rspBody, &rsp)
 	if err != nil {
@@ -458,3 +435,23 @@ func (gc *GrafanaClient_5_0) CreateNotificationChannel(nc *NotificationChannel)
 	nc.ID = rsp.ID
 	return nil
 }
+
+// WithOrg 返回一个新的Client，它发出的每一个请求都会带上X-Grafana-Org-Id，从而操作指定的Organization，
+// 而不是当前登录用户所在的Organization。原Client不受影响，两者共享底层的连接、限流器等资源。
+func (gc *GrafanaClient_5_0) WithOrg(orgID int) GrafanaClienter {
+	return &GrafanaClient_5_0{baseClient: gc.withOrg(orgID)}
+}
+
+// SwitchUserOrg 将当前登录用户的活动Organization切换为orgID，这会影响该用户之后所有不带X-Grafana-Org-Id的请求
+func (gc *GrafanaClient_5_0) SwitchUserOrg(orgID int) error {
+	return gc.SwitchUserOrgCtx(context.Background(), orgID)
+}
+
+func (gc *GrafanaClient_5_0) SwitchUserOrgCtx(ctx context.Context, orgID int) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/user/using/%d", gc.basicAddress, orgID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = gc.getHTTPResponse(req, "SwitchUserOrg(api/user/using/[ORG-ID])")
+	return err
+}
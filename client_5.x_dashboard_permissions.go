@@ -0,0 +1,82 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetDashboardPermissions 获取Dashboard的授权，其中也包含继承自所在Folder的授权(Inherited为true)。
+// Grafana 5.x只提供基于Dashboard ID的API，因此这里会先通过UID获取Dashboard的ID。
+func (gc *GrafanaClient_5_0) GetDashboardPermissions(uid string) ([]PermissionEntry, error) {
+	return gc.GetDashboardPermissionsCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_5_0) GetDashboardPermissionsCtx(ctx context.Context, uid string) ([]PermissionEntry, error) {
+	board, err := gc.GetDashboardDetailsCtx(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return gc.GetDashboardPermissionsByIDCtx(ctx, int(board.ID))
+}
+
+func (gc *GrafanaClient_5_0) GetDashboardPermissionsByID(dashboardID int) ([]PermissionEntry, error) {
+	return gc.GetDashboardPermissionsByIDCtx(context.Background(), dashboardID)
+}
+
+func (gc *GrafanaClient_5_0) GetDashboardPermissionsByIDCtx(ctx context.Context, dashboardID int) ([]PermissionEntry, error) {
+	var entries []PermissionEntry
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/dashboards/id/%d/permissions", dashboardID), nil, &entries, "GetDashboardPermissions(api/dashboards/id/[DASHBOARD-ID]/permissions)")
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UpdateDashboardPermissions 使用items替换Dashboard上直接设置的全部授权，继承自Folder的授权不受影响
+func (gc *GrafanaClient_5_0) UpdateDashboardPermissions(uid string, items []PermissionItem) error {
+	return gc.UpdateDashboardPermissionsCtx(context.Background(), uid, items)
+}
+
+func (gc *GrafanaClient_5_0) UpdateDashboardPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) error {
+	board, err := gc.GetDashboardDetailsCtx(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return gc.UpdateDashboardPermissionsByIDCtx(ctx, int(board.ID), items)
+}
+
+func (gc *GrafanaClient_5_0) UpdateDashboardPermissionsByID(dashboardID int, items []PermissionItem) error {
+	return gc.UpdateDashboardPermissionsByIDCtx(context.Background(), dashboardID, items)
+}
+
+func (gc *GrafanaClient_5_0) UpdateDashboardPermissionsByIDCtx(ctx context.Context, dashboardID int, items []PermissionItem) error {
+	if items == nil {
+		items = []PermissionItem{}
+	}
+	body := UpdatePermissionsRequest{Items: items}
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/dashboards/id/%d/permissions", dashboardID), body, nil, "UpdateDashboardPermissions(api/dashboards/id/[DASHBOARD-ID]/permissions)")
+}
+
+// EnsureDashboardPermissions 只在Dashboard上直接设置的授权与items不一致时才更新，返回是否进行了更新
+func (gc *GrafanaClient_5_0) EnsureDashboardPermissions(uid string, items []PermissionItem) (bool, error) {
+	return gc.EnsureDashboardPermissionsCtx(context.Background(), uid, items)
+}
+
+func (gc *GrafanaClient_5_0) EnsureDashboardPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) (bool, error) {
+	board, err := gc.GetDashboardDetailsCtx(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	entries, err := gc.GetDashboardPermissionsByIDCtx(ctx, int(board.ID))
+	if err != nil {
+		return false, err
+	}
+	if permissionsEqual(ownPermissionItems(entries), items) {
+		return false, nil
+	}
+	err = gc.UpdateDashboardPermissionsByIDCtx(ctx, int(board.ID), items)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
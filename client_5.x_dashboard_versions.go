@@ -0,0 +1,99 @@
+package gografana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ListDashboardVersions 按照从新到旧的顺序列举Dashboard的历史版本，limit<=0时使用Grafana的默认值，start为跳过的版本数。
+// Grafana 5.x只提供基于Dashboard ID的API，因此这里会先通过UID获取Dashboard的ID。
+func (gc *GrafanaClient_5_0) ListDashboardVersions(uid string, limit, start int) ([]DashboardVersion, error) {
+	return gc.ListDashboardVersionsCtx(context.Background(), uid, limit, start)
+}
+
+func (gc *GrafanaClient_5_0) ListDashboardVersionsCtx(ctx context.Context, uid string, limit, start int) ([]DashboardVersion, error) {
+	board, err := gc.GetDashboardDetailsCtx(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	urlPath := fmt.Sprintf("/api/dashboards/id/%d/versions", board.ID) + dashboardVersionsQuery(limit, start)
+	err = gc.callAPI(ctx, "GET", urlPath, nil, &raw, "ListDashboardVersions(api/dashboards/id/[DASHBOARD-ID]/versions)")
+	if err != nil {
+		return nil, err
+	}
+	return parseDashboardVersions(raw, "ListDashboardVersions(api/dashboards/id/[DASHBOARD-ID]/versions)")
+}
+
+// GetDashboardVersion 获取Dashboard的指定版本，其中包含该版本保存的完整Dashboard
+func (gc *GrafanaClient_5_0) GetDashboardVersion(uid string, version int) (*DashboardVersionDetail, error) {
+	return gc.GetDashboardVersionCtx(context.Background(), uid, version)
+}
+
+func (gc *GrafanaClient_5_0) GetDashboardVersionCtx(ctx context.Context, uid string, version int) (*DashboardVersionDetail, error) {
+	board, err := gc.GetDashboardDetailsCtx(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	var detail DashboardVersionDetail
+	err = gc.callAPI(ctx, "GET", fmt.Sprintf("/api/dashboards/id/%d/versions/%d", board.ID, version), nil, &detail, "GetDashboardVersion(api/dashboards/id/[DASHBOARD-ID]/versions/[VERSION])")
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// RestoreDashboardVersion 将Dashboard恢复到指定的版本，Grafana会以此创建一个新的版本
+func (gc *GrafanaClient_5_0) RestoreDashboardVersion(uid string, version int) (*CreateDashboardResponse, error) {
+	return gc.RestoreDashboardVersionCtx(context.Background(), uid, version)
+}
+
+func (gc *GrafanaClient_5_0) RestoreDashboardVersionCtx(ctx context.Context, uid string, version int) (*CreateDashboardResponse, error) {
+	board, err := gc.GetDashboardDetailsCtx(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	var rsp CreateDashboardResponse
+	body := RestoreDashboardVersionRequest{Version: version}
+	err = gc.callAPI(ctx, "POST", fmt.Sprintf("/api/dashboards/id/%d/restore", board.ID), body, &rsp, "RestoreDashboardVersion(api/dashboards/id/[DASHBOARD-ID]/restore)")
+	if err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func dashboardVersionsQuery(limit, start int) string {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", limit))
+	}
+	if start > 0 {
+		params.Set("start", fmt.Sprintf("%d", start))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
+// parseDashboardVersions 兼容两种返回格式: 早期版本直接返回数组，Grafana 11+返回{"versions": [...]}
+func parseDashboardVersions(raw json.RawMessage, flag string) ([]DashboardVersion, error) {
+	var versions []DashboardVersion
+	var err error
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var rsp struct {
+			Versions []DashboardVersion `json:"versions"`
+		}
+		err = json.Unmarshal(trimmed, &rsp)
+		versions = rsp.Versions
+	} else {
+		err = json.Unmarshal(raw, &versions)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal response body failed while calling to API %s, error: %s", flag, err.Error())
+	}
+	return versions, nil
+}
@@ -0,0 +1,59 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Status Codes:
+// -------------------
+// 200 – Found
+// 401 – Unauthorized
+// 403 – Access denied
+// 404 – Folder not found
+func (gc *GrafanaClient_5_0) GetFolderPermissions(uid string) ([]PermissionEntry, error) {
+	return gc.GetFolderPermissionsCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_5_0) GetFolderPermissionsCtx(ctx context.Context, uid string) ([]PermissionEntry, error) {
+	var entries []PermissionEntry
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/folders/%s/permissions", url.PathEscape(uid)), nil, &entries, "GetFolderPermissions(api/folders/[UID]/permissions)")
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UpdateFolderPermissions 使用items替换Folder现有的全部授权，items为空会移除所有授权(Admin角色除外)
+func (gc *GrafanaClient_5_0) UpdateFolderPermissions(uid string, items []PermissionItem) error {
+	return gc.UpdateFolderPermissionsCtx(context.Background(), uid, items)
+}
+
+func (gc *GrafanaClient_5_0) UpdateFolderPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) error {
+	if items == nil {
+		items = []PermissionItem{}
+	}
+	body := UpdatePermissionsRequest{Items: items}
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/folders/%s/permissions", url.PathEscape(uid)), body, nil, "UpdateFolderPermissions(api/folders/[UID]/permissions)")
+}
+
+// EnsureFolderPermissions 只在Folder当前的授权与items不一致时才更新，返回是否进行了更新
+func (gc *GrafanaClient_5_0) EnsureFolderPermissions(uid string, items []PermissionItem) (bool, error) {
+	return gc.EnsureFolderPermissionsCtx(context.Background(), uid, items)
+}
+
+func (gc *GrafanaClient_5_0) EnsureFolderPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) (bool, error) {
+	entries, err := gc.GetFolderPermissionsCtx(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	if permissionsEqual(ownPermissionItems(entries), items) {
+		return false, nil
+	}
+	err = gc.UpdateFolderPermissionsCtx(ctx, uid, items)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Status Codes:
+// -------------------
+// 200 – Found
+// 401 – Unauthorized
+// 403 – Access denied
+// 404 – Folder not found
+func (gc *GrafanaClient_5_0) GetFolderByUID(uid string) (*Folder, error) {
+	return gc.GetFolderByUIDCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_5_0) GetFolderByUIDCtx(ctx context.Context, uid string) (*Folder, error) {
+	var folder Folder
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/folders/%s", url.PathEscape(uid)), nil, &folder, "GetFolderByUID(api/folders/[UID])")
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func (gc *GrafanaClient_5_0) GetFolderByID(folderId int) (*Folder, error) {
+	return gc.GetFolderByIDCtx(context.Background(), folderId)
+}
+
+func (gc *GrafanaClient_5_0) GetFolderByIDCtx(ctx context.Context, folderId int) (*Folder, error) {
+	var folder Folder
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/folders/id/%d", folderId), nil, &folder, "GetFolderByID(api/folders/id/[FOLDER-ID])")
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// CreateFolder 使用folder的UID(为空时由Grafana生成)和Title创建Folder，成功后folder会被更新为Grafana返回的内容
+//
+// Status Codes:
+// -------------------
+// 200 – Created
+// 400 – Errors
+// 403 – Access denied
+// 409 – Folder already exists
+func (gc *GrafanaClient_5_0) CreateFolder(folder *Folder) error {
+	return gc.CreateFolderCtx(context.Background(), folder)
+}
+
+func (gc *GrafanaClient_5_0) CreateFolderCtx(ctx context.Context, folder *Folder) error {
+	body := CreateFolderRequest{UID: folder.UID, Title: folder.Title, ParentUID: folder.ParentUID}
+	return gc.callAPI(ctx, "POST", "/api/folders", body, folder, "CreateFolder(api/folders)")
+}
+
+// UpdateFolder 更新Folder的Title，folder.Version需要是更新所基于的版本，
+// 如果期间Folder被其他人修改过，Grafana会返回412(IsVersionMismatch)，除非overwrite为true。
+// 成功后folder会被更新为Grafana返回的内容。
+func (gc *GrafanaClient_5_0) UpdateFolder(folder *Folder, overwrite bool) error {
+	return gc.UpdateFolderCtx(context.Background(), folder, overwrite)
+}
+
+func (gc *GrafanaClient_5_0) UpdateFolderCtx(ctx context.Context, folder *Folder, overwrite bool) error {
+	body := UpdateFolderRequest{UID: folder.UID, Title: folder.Title, Version: folder.Version, Overwrite: overwrite}
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/folders/%s", url.PathEscape(folder.UID)), body, folder, "UpdateFolder(api/folders/[UID])")
+}
+
+// DeleteFolder 删除Folder以及其中所有的Dashboard，
+// forceDeleteRules为true时同时删除其中的告警规则(否则Folder中存在告警规则时Grafana会拒绝删除)。
+func (gc *GrafanaClient_5_0) DeleteFolder(uid string, forceDeleteRules bool) error {
+	return gc.DeleteFolderCtx(context.Background(), uid, forceDeleteRules)
+}
+
+func (gc *GrafanaClient_5_0) DeleteFolderCtx(ctx context.Context, uid string, forceDeleteRules bool) error {
+	urlPath := fmt.Sprintf("/api/folders/%s", url.PathEscape(uid))
+	if forceDeleteRules {
+		urlPath += "?forceDeleteRules=true"
+	}
+	return gc.callAPI(ctx, "DELETE", urlPath, nil, nil, "DeleteFolder(api/folders/[UID])")
+}
+
+// EnsureFolder 通过UID查找Folder，不存在时使用uid和title创建它，
+// 无论是找到的还是新创建的，都会返回Folder的完整信息(包括真实的ID和UID)，第二个返回值表示是否进行了创建。
+func (gc *GrafanaClient_5_0) EnsureFolder(uid, title string) (*Folder, bool, error) {
+	return gc.EnsureFolderCtx(context.Background(), uid, title)
+}
+
+func (gc *GrafanaClient_5_0) EnsureFolderCtx(ctx context.Context, uid, title string) (*Folder, bool, error) {
+	folder, err := gc.GetFolderByUIDCtx(ctx, uid)
+	if err == nil {
+		return folder, false, nil
+	}
+	if !IsNotFound(err) {
+		return nil, false, err
+	}
+	folder = &Folder{UID: uid, Title: title}
+	err = gc.CreateFolderCtx(ctx, folder)
+	if err != nil {
+		return nil, false, err
+	}
+	return folder, true, nil
+}
@@ -0,0 +1,163 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Grafana的/api/orgs默认每页返回1000个Organization
+const orgsPerPage = 1000
+
+// GetAllOrgs 列举所有的Organization，需要Grafana Server Admin权限
+func (gc *GrafanaClient_5_0) GetAllOrgs() ([]Org, error) {
+	return gc.GetAllOrgsCtx(context.Background())
+}
+
+func (gc *GrafanaClient_5_0) GetAllOrgsCtx(ctx context.Context) ([]Org, error) {
+	var orgs []Org
+	for page := 1; ; page++ {
+		var pageOrgs []Org
+		err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/orgs?perpage=%d&page=%d", orgsPerPage, page), nil, &pageOrgs, "GetAllOrgs(api/orgs)")
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, pageOrgs...)
+		if len(pageOrgs) < orgsPerPage {
+			return orgs, nil
+		}
+	}
+}
+
+// Status Codes:
+// -------------------
+// 200 – Found
+// 403 – Access denied
+// 404 – Not found
+func (gc *GrafanaClient_5_0) GetOrgByID(orgID int) (*Org, error) {
+	return gc.GetOrgByIDCtx(context.Background(), orgID)
+}
+
+func (gc *GrafanaClient_5_0) GetOrgByIDCtx(ctx context.Context, orgID int) (*Org, error) {
+	var org Org
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/orgs/%d", orgID), nil, &org, "GetOrgByID(api/orgs/[ORG-ID])")
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (gc *GrafanaClient_5_0) GetOrgByName(name string) (*Org, error) {
+	return gc.GetOrgByNameCtx(context.Background(), name)
+}
+
+func (gc *GrafanaClient_5_0) GetOrgByNameCtx(ctx context.Context, name string) (*Org, error) {
+	var org Org
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/orgs/name/%s", url.PathEscape(name)), nil, &org, "GetOrgByName(api/orgs/name/[NAME])")
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// CreateOrg 创建一个新的Organization，成功后会设置org.ID
+func (gc *GrafanaClient_5_0) CreateOrg(org *Org) error {
+	return gc.CreateOrgCtx(context.Background(), org)
+}
+
+func (gc *GrafanaClient_5_0) CreateOrgCtx(ctx context.Context, org *Org) error {
+	var rsp CreateOrgResponse
+	err := gc.callAPI(ctx, "POST", "/api/orgs", map[string]string{"name": org.Name}, &rsp, "CreateOrg(api/orgs)")
+	if err != nil {
+		return err
+	}
+	org.ID = rsp.OrgID
+	return nil
+}
+
+// UpdateOrg 更新Organization的名称
+func (gc *GrafanaClient_5_0) UpdateOrg(org *Org) error {
+	return gc.UpdateOrgCtx(context.Background(), org)
+}
+
+func (gc *GrafanaClient_5_0) UpdateOrgCtx(ctx context.Context, org *Org) error {
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/orgs/%d", org.ID), map[string]string{"name": org.Name}, nil, "UpdateOrg(api/orgs/[ORG-ID])")
+}
+
+func (gc *GrafanaClient_5_0) UpdateOrgAddress(orgID int, address OrgAddress) error {
+	return gc.UpdateOrgAddressCtx(context.Background(), orgID, address)
+}
+
+func (gc *GrafanaClient_5_0) UpdateOrgAddressCtx(ctx context.Context, orgID int, address OrgAddress) error {
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/orgs/%d/address", orgID), address, nil, "UpdateOrgAddress(api/orgs/[ORG-ID]/address)")
+}
+
+func (gc *GrafanaClient_5_0) DeleteOrg(orgID int) error {
+	return gc.DeleteOrgCtx(context.Background(), orgID)
+}
+
+func (gc *GrafanaClient_5_0) DeleteOrgCtx(ctx context.Context, orgID int) error {
+	return gc.callAPI(ctx, "DELETE", fmt.Sprintf("/api/orgs/%d", orgID), nil, nil, "DeleteOrg(api/orgs/[ORG-ID])")
+}
+
+func (gc *GrafanaClient_5_0) GetOrgUsers(orgID int) ([]OrgUser, error) {
+	return gc.GetOrgUsersCtx(context.Background(), orgID)
+}
+
+func (gc *GrafanaClient_5_0) GetOrgUsersCtx(ctx context.Context, orgID int) ([]OrgUser, error) {
+	var users []OrgUser
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/orgs/%d/users", orgID), nil, &users, "GetOrgUsers(api/orgs/[ORG-ID]/users)")
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// AddOrgUser 将一个已存在的用户以role(OrgRoleViewer/OrgRoleEditor/OrgRoleAdmin)加入到Organization中
+func (gc *GrafanaClient_5_0) AddOrgUser(orgID int, loginOrEmail, role string) error {
+	return gc.AddOrgUserCtx(context.Background(), orgID, loginOrEmail, role)
+}
+
+func (gc *GrafanaClient_5_0) AddOrgUserCtx(ctx context.Context, orgID int, loginOrEmail, role string) error {
+	body := map[string]string{"loginOrEmail": loginOrEmail, "role": role}
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/orgs/%d/users", orgID), body, nil, "AddOrgUser(api/orgs/[ORG-ID]/users)")
+}
+
+func (gc *GrafanaClient_5_0) UpdateOrgUserRole(orgID, userID int, role string) error {
+	return gc.UpdateOrgUserRoleCtx(context.Background(), orgID, userID, role)
+}
+
+func (gc *GrafanaClient_5_0) UpdateOrgUserRoleCtx(ctx context.Context, orgID, userID int, role string) error {
+	return gc.callAPI(ctx, "PATCH", fmt.Sprintf("/api/orgs/%d/users/%d", orgID, userID), map[string]string{"role": role}, nil, "UpdateOrgUserRole(api/orgs/[ORG-ID]/users/[USER-ID])")
+}
+
+func (gc *GrafanaClient_5_0) RemoveOrgUser(orgID, userID int) error {
+	return gc.RemoveOrgUserCtx(context.Background(), orgID, userID)
+}
+
+func (gc *GrafanaClient_5_0) RemoveOrgUserCtx(ctx context.Context, orgID, userID int) error {
+	return gc.callAPI(ctx, "DELETE", fmt.Sprintf("/api/orgs/%d/users/%d", orgID, userID), nil, nil, "RemoveOrgUser(api/orgs/[ORG-ID]/users/[USER-ID])")
+}
+
+// GetOrgPreferences 获取当前Organization的偏好设置，配合WithOrg可以获取指定Organization的偏好设置
+func (gc *GrafanaClient_5_0) GetOrgPreferences() (*Preferences, error) {
+	return gc.GetOrgPreferencesCtx(context.Background())
+}
+
+func (gc *GrafanaClient_5_0) GetOrgPreferencesCtx(ctx context.Context) (*Preferences, error) {
+	var prefs Preferences
+	err := gc.callAPI(ctx, "GET", "/api/org/preferences", nil, &prefs, "GetOrgPreferences(api/org/preferences)")
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// UpdateOrgPreferences 更新当前Organization的偏好设置，配合WithOrg可以更新指定Organization的偏好设置
+func (gc *GrafanaClient_5_0) UpdateOrgPreferences(prefs *Preferences) error {
+	return gc.UpdateOrgPreferencesCtx(context.Background(), prefs)
+}
+
+func (gc *GrafanaClient_5_0) UpdateOrgPreferencesCtx(ctx context.Context, prefs *Preferences) error {
+	return gc.callAPI(ctx, "PUT", "/api/org/preferences", prefs, nil, "UpdateOrgPreferences(api/org/preferences)")
+}
@@ -0,0 +1,212 @@
+package gografana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	SearchTypeDashboard = "dash-db"
+	SearchTypeFolder    = "dash-folder"
+)
+
+// 自动翻页时每页请求的数量，与Grafana /api/search的默认limit一致
+const searchPerPage = 1000
+
+// SearchQuery 对应/api/search支持的全部查询参数，零值的字段不会出现在请求中
+type SearchQuery struct {
+	Query         string
+	Tags          []string
+	Type          string
+	DashboardIDs  []int
+	DashboardUIDs []string
+	FolderIDs     []int
+	FolderUIDs    []string
+	Starred       bool
+	//每页的数量，<=0时使用1000
+	Limit int
+	//大于0时只返回该页的结果，否则自动翻页返回全部结果
+	Page int
+}
+
+func (q SearchQuery) values() url.Values {
+	params := url.Values{}
+	if q.Query != "" {
+		params.Set("query", q.Query)
+	}
+	for _, tag := range q.Tags {
+		params.Add("tag", tag)
+	}
+	if q.Type != "" {
+		params.Set("type", q.Type)
+	}
+	for _, id := range q.DashboardIDs {
+		params.Add("dashboardIds", strconv.Itoa(id))
+	}
+	for _, uid := range q.DashboardUIDs {
+		params.Add("dashboardUIDs", uid)
+	}
+	for _, id := range q.FolderIDs {
+		params.Add("folderIds", strconv.Itoa(id))
+	}
+	for _, uid := range q.FolderUIDs {
+		params.Add("folderUIDs", uid)
+	}
+	if q.Starred {
+		params.Set("starred", "true")
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Page > 0 {
+		params.Set("page", strconv.Itoa(q.Page))
+	}
+	return params
+}
+
+// Search 按照query搜索Dashboard和Folder，query.Page为0时会自动翻页直到取得全部结果
+func (gc *GrafanaClient_5_0) Search(query SearchQuery) ([]SearchHit, error) {
+	return gc.SearchCtx(context.Background(), query)
+}
+
+func (gc *GrafanaClient_5_0) SearchCtx(ctx context.Context, query SearchQuery) ([]SearchHit, error) {
+	return gc.search(ctx, query, "Search(api/search)")
+}
+
+// SearchIter 返回一个按需翻页的迭代器，适用于Dashboard数量很多、不希望一次性加载全部结果的场景:
+//
+//	it := client.SearchIter(gografana.SearchQuery{Type: gografana.SearchTypeDashboard})
+//	for it.Next() {
+//		hit := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+func (gc *GrafanaClient_5_0) SearchIter(query SearchQuery) *SearchIterator {
+	return gc.SearchIterCtx(context.Background(), query)
+}
+
+func (gc *GrafanaClient_5_0) SearchIterCtx(ctx context.Context, query SearchQuery) *SearchIterator {
+	return gc.searchIter(ctx, query, "Search(api/search)")
+}
+
+func (gc *GrafanaClient_5_0) search(ctx context.Context, query SearchQuery, flag string) ([]SearchHit, error) {
+	if query.Page > 0 {
+		return gc.searchPage(ctx, query, flag)
+	}
+	var hits []SearchHit
+	it := gc.searchIter(ctx, query, flag)
+	for it.Next() {
+		hits = append(hits, it.Item())
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return hits, nil
+}
+
+func (gc *GrafanaClient_5_0) searchIter(ctx context.Context, query SearchQuery, flag string) *SearchIterator {
+	return &SearchIterator{
+		ctx:   ctx,
+		query: query,
+		fetch: func(ctx context.Context, q SearchQuery) ([]SearchHit, error) {
+			return gc.searchPage(ctx, q, flag)
+		},
+	}
+}
+
+func (gc *GrafanaClient_5_0) searchPage(ctx context.Context, query SearchQuery, flag string) ([]SearchHit, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/search?%s", gc.basicAddress, query.values().Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyData, err := gc.getHTTPResponse(req, flag)
+	if err != nil {
+		return nil, err
+	}
+	var hits []SearchHit
+	err = json.Unmarshal(bodyData, &hits)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal response body failed while calling to API %s, error: %s", flag, err.Error())
+	}
+	return hits, nil
+}
+
+// SearchIterator 逐条返回搜索结果，并在当前页用完时请求下一页，不能被多个goroutine并发使用
+type SearchIterator struct {
+	ctx   context.Context
+	query SearchQuery
+	fetch func(ctx context.Context, q SearchQuery) ([]SearchHit, error)
+	items []SearchHit
+	index int
+	//第一页的第一个结果，用来识别不支持page参数(总是返回第一页)的旧版本Grafana
+	first *SearchHit
+	done  bool
+	err   error
+}
+
+// Next 移动到下一个结果，没有更多结果或者出错时返回false
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index+1 < len(it.items) {
+		it.index++
+		return true
+	}
+	if it.done {
+		return false
+	}
+	limit := it.query.Limit
+	if limit <= 0 {
+		limit = searchPerPage
+	}
+	q := it.query
+	q.Limit = limit
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	items, err := it.fetch(it.ctx, q)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if it.first != nil && len(items) > 0 && items[0].ID == it.first.ID && items[0].UID == it.first.UID {
+		it.done = true
+		return false
+	}
+	if it.first == nil && len(items) > 0 {
+		it.first = &items[0]
+	}
+	it.query.Page = q.Page + 1
+	it.items, it.index = items, 0
+	if len(items) < limit {
+		it.done = true
+	}
+	return len(items) > 0
+}
+
+// Item 返回当前的结果，只能在Next返回true之后调用
+func (it *SearchIterator) Item() SearchHit {
+	return it.items[it.index]
+}
+
+// Err 返回翻页过程中遇到的错误
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// GetDashboardForHit 获取搜索结果对应的完整Dashboard
+func (gc *GrafanaClient_5_0) GetDashboardForHit(hit SearchHit) (*Board, error) {
+	return gc.GetDashboardForHitCtx(context.Background(), hit)
+}
+
+func (gc *GrafanaClient_5_0) GetDashboardForHitCtx(ctx context.Context, hit SearchHit) (*Board, error) {
+	if hit.Type == SearchTypeFolder {
+		return nil, fmt.Errorf("search hit %q is a folder, not a dashboard", hit.Title)
+	}
+	return gc.GetDashboardDetailsCtx(ctx, hit.UID)
+}
@@ -0,0 +1,165 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// SearchTeams 按照名称搜索当前Organization中的Team，query为空时返回所有Team，page从1开始
+func (gc *GrafanaClient_5_0) SearchTeams(query string, perPage, page int) (*SearchTeamsResponse, error) {
+	return gc.SearchTeamsCtx(context.Background(), query, perPage, page)
+}
+
+func (gc *GrafanaClient_5_0) SearchTeamsCtx(ctx context.Context, query string, perPage, page int) (*SearchTeamsResponse, error) {
+	params := url.Values{}
+	params.Set("perpage", strconv.Itoa(perPage))
+	params.Set("page", strconv.Itoa(page))
+	if query != "" {
+		params.Set("query", query)
+	}
+	var rsp SearchTeamsResponse
+	err := gc.callAPI(ctx, "GET", "/api/teams/search?"+params.Encode(), nil, &rsp, "SearchTeams(api/teams/search)")
+	if err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// Status Codes:
+// -------------------
+// 200 – Found
+// 404 – Not found
+func (gc *GrafanaClient_5_0) GetTeamByID(teamID int) (*Team, error) {
+	return gc.GetTeamByIDCtx(context.Background(), teamID)
+}
+
+func (gc *GrafanaClient_5_0) GetTeamByIDCtx(ctx context.Context, teamID int) (*Team, error) {
+	var team Team
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/teams/%d", teamID), nil, &team, "GetTeamByID(api/teams/[TEAM-ID])")
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// CreateTeam 在当前Organization中创建Team，成功后会设置team.ID
+func (gc *GrafanaClient_5_0) CreateTeam(team *Team) error {
+	return gc.CreateTeamCtx(context.Background(), team)
+}
+
+func (gc *GrafanaClient_5_0) CreateTeamCtx(ctx context.Context, team *Team) error {
+	body := map[string]string{"name": team.Name, "email": team.Email}
+	var rsp CreateTeamResponse
+	err := gc.callAPI(ctx, "POST", "/api/teams", body, &rsp, "CreateTeam(api/teams)")
+	if err != nil {
+		return err
+	}
+	team.ID = rsp.TeamID
+	return nil
+}
+
+// UpdateTeam 更新Team的名称和email
+func (gc *GrafanaClient_5_0) UpdateTeam(team *Team) error {
+	return gc.UpdateTeamCtx(context.Background(), team)
+}
+
+func (gc *GrafanaClient_5_0) UpdateTeamCtx(ctx context.Context, team *Team) error {
+	body := map[string]string{"name": team.Name, "email": team.Email}
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/teams/%d", team.ID), body, nil, "UpdateTeam(api/teams/[TEAM-ID])")
+}
+
+func (gc *GrafanaClient_5_0) DeleteTeam(teamID int) error {
+	return gc.DeleteTeamCtx(context.Background(), teamID)
+}
+
+func (gc *GrafanaClient_5_0) DeleteTeamCtx(ctx context.Context, teamID int) error {
+	return gc.callAPI(ctx, "DELETE", fmt.Sprintf("/api/teams/%d", teamID), nil, nil, "DeleteTeam(api/teams/[TEAM-ID])")
+}
+
+func (gc *GrafanaClient_5_0) GetTeamMembers(teamID int) ([]TeamMember, error) {
+	return gc.GetTeamMembersCtx(context.Background(), teamID)
+}
+
+func (gc *GrafanaClient_5_0) GetTeamMembersCtx(ctx context.Context, teamID int) ([]TeamMember, error) {
+	var members []TeamMember
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/teams/%d/members", teamID), nil, &members, "GetTeamMembers(api/teams/[TEAM-ID]/members)")
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (gc *GrafanaClient_5_0) AddTeamMember(teamID, userID int) error {
+	return gc.AddTeamMemberCtx(context.Background(), teamID, userID)
+}
+
+func (gc *GrafanaClient_5_0) AddTeamMemberCtx(ctx context.Context, teamID, userID int) error {
+	body := map[string]int{"userId": userID}
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/teams/%d/members", teamID), body, nil, "AddTeamMember(api/teams/[TEAM-ID]/members)")
+}
+
+func (gc *GrafanaClient_5_0) RemoveTeamMember(teamID, userID int) error {
+	return gc.RemoveTeamMemberCtx(context.Background(), teamID, userID)
+}
+
+func (gc *GrafanaClient_5_0) RemoveTeamMemberCtx(ctx context.Context, teamID, userID int) error {
+	return gc.callAPI(ctx, "DELETE", fmt.Sprintf("/api/teams/%d/members/%d", teamID, userID), nil, nil, "RemoveTeamMember(api/teams/[TEAM-ID]/members/[USER-ID])")
+}
+
+// SetTeamMembers 将Team的成员同步为userIDs: 添加缺少的成员并移除多余的成员，已经是成员的用户不受影响
+func (gc *GrafanaClient_5_0) SetTeamMembers(teamID int, userIDs []int) error {
+	return gc.SetTeamMembersCtx(context.Background(), teamID, userIDs)
+}
+
+func (gc *GrafanaClient_5_0) SetTeamMembersCtx(ctx context.Context, teamID int, userIDs []int) error {
+	members, err := gc.GetTeamMembersCtx(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[int]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+	existing := make(map[int]bool, len(members))
+	for _, m := range members {
+		existing[m.UserID] = true
+		if !wanted[m.UserID] {
+			if err = gc.RemoveTeamMemberCtx(ctx, teamID, m.UserID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range userIDs {
+		if existing[id] {
+			continue
+		}
+		if err = gc.AddTeamMemberCtx(ctx, teamID, id); err != nil {
+			return err
+		}
+		existing[id] = true
+	}
+	return nil
+}
+
+func (gc *GrafanaClient_5_0) GetTeamPreferences(teamID int) (*Preferences, error) {
+	return gc.GetTeamPreferencesCtx(context.Background(), teamID)
+}
+
+func (gc *GrafanaClient_5_0) GetTeamPreferencesCtx(ctx context.Context, teamID int) (*Preferences, error) {
+	var prefs Preferences
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/teams/%d/preferences", teamID), nil, &prefs, "GetTeamPreferences(api/teams/[TEAM-ID]/preferences)")
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+func (gc *GrafanaClient_5_0) UpdateTeamPreferences(teamID int, prefs *Preferences) error {
+	return gc.UpdateTeamPreferencesCtx(context.Background(), teamID, prefs)
+}
+
+func (gc *GrafanaClient_5_0) UpdateTeamPreferencesCtx(ctx context.Context, teamID int, prefs *Preferences) error {
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/teams/%d/preferences", teamID), prefs, nil, "UpdateTeamPreferences(api/teams/[TEAM-ID]/preferences)")
+}
@@ -0,0 +1,171 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// SearchUsers 按照login、email或者name搜索用户，query为空时返回所有用户，page从1开始
+func (gc *GrafanaClient_5_0) SearchUsers(query string, perPage, page int) (*SearchUsersResponse, error) {
+	return gc.SearchUsersCtx(context.Background(), query, perPage, page)
+}
+
+func (gc *GrafanaClient_5_0) SearchUsersCtx(ctx context.Context, query string, perPage, page int) (*SearchUsersResponse, error) {
+	params := url.Values{}
+	params.Set("perpage", fmt.Sprintf("%d", perPage))
+	params.Set("page", fmt.Sprintf("%d", page))
+	if query != "" {
+		params.Set("query", query)
+	}
+	var rsp SearchUsersResponse
+	err := gc.callAPI(ctx, "GET", "/api/users/search?"+params.Encode(), nil, &rsp, "SearchUsers(api/users/search)")
+	if err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// Status Codes:
+// -------------------
+// 200 – Found
+// 404 – Not found
+func (gc *GrafanaClient_5_0) GetUserByID(userID int) (*User, error) {
+	return gc.GetUserByIDCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) GetUserByIDCtx(ctx context.Context, userID int) (*User, error) {
+	var user User
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/users/%d", userID), nil, &user, "GetUserByID(api/users/[USER-ID])")
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByLoginOrEmail 通过用户的login或者email获取用户
+func (gc *GrafanaClient_5_0) GetUserByLoginOrEmail(loginOrEmail string) (*User, error) {
+	return gc.GetUserByLoginOrEmailCtx(context.Background(), loginOrEmail)
+}
+
+func (gc *GrafanaClient_5_0) GetUserByLoginOrEmailCtx(ctx context.Context, loginOrEmail string) (*User, error) {
+	var user User
+	err := gc.callAPI(ctx, "GET", "/api/users/lookup?loginOrEmail="+url.QueryEscape(loginOrEmail), nil, &user, "GetUserByLoginOrEmail(api/users/lookup)")
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUser 更新用户的email、name、login以及theme
+func (gc *GrafanaClient_5_0) UpdateUser(user *User) error {
+	return gc.UpdateUserCtx(context.Background(), user)
+}
+
+func (gc *GrafanaClient_5_0) UpdateUserCtx(ctx context.Context, user *User) error {
+	body := map[string]string{"email": user.Email, "name": user.Name, "login": user.Login, "theme": user.Theme}
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/users/%d", user.ID), body, nil, "UpdateUser(api/users/[USER-ID])")
+}
+
+func (gc *GrafanaClient_5_0) GetUserOrgs(userID int) ([]UserOrg, error) {
+	return gc.GetUserOrgsCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) GetUserOrgsCtx(ctx context.Context, userID int) ([]UserOrg, error) {
+	var orgs []UserOrg
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/users/%d/orgs", userID), nil, &orgs, "GetUserOrgs(api/users/[USER-ID]/orgs)")
+	if err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+func (gc *GrafanaClient_5_0) GetUserTeams(userID int) ([]Team, error) {
+	return gc.GetUserTeamsCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) GetUserTeamsCtx(ctx context.Context, userID int) ([]Team, error) {
+	var teams []Team
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/users/%d/teams", userID), nil, &teams, "GetUserTeams(api/users/[USER-ID]/teams)")
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// CreateUser 通过Admin API创建用户，成功后会设置user.ID，需要Grafana Server Admin权限。
+// user.OrgID不为0时用户会被加入到该Organization中。
+func (gc *GrafanaClient_5_0) CreateUser(user *User, password string) error {
+	return gc.CreateUserCtx(context.Background(), user, password)
+}
+
+func (gc *GrafanaClient_5_0) CreateUserCtx(ctx context.Context, user *User, password string) error {
+	body := map[string]interface{}{
+		"name":     user.Name,
+		"email":    user.Email,
+		"login":    user.Login,
+		"password": password,
+	}
+	if user.OrgID != 0 {
+		body["OrgId"] = user.OrgID
+	}
+	var rsp CreateUserResponse
+	err := gc.callAPI(ctx, "POST", "/api/admin/users", body, &rsp, "CreateUser(api/admin/users)")
+	if err != nil {
+		return err
+	}
+	user.ID = rsp.ID
+	return nil
+}
+
+func (gc *GrafanaClient_5_0) SetUserPassword(userID int, password string) error {
+	return gc.SetUserPasswordCtx(context.Background(), userID, password)
+}
+
+func (gc *GrafanaClient_5_0) SetUserPasswordCtx(ctx context.Context, userID int, password string) error {
+	body := map[string]string{"password": password}
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/admin/users/%d/password", userID), body, nil, "SetUserPassword(api/admin/users/[USER-ID]/password)")
+}
+
+// SetUserGrafanaAdmin 设置用户是否为Grafana Server Admin
+func (gc *GrafanaClient_5_0) SetUserGrafanaAdmin(userID int, isGrafanaAdmin bool) error {
+	return gc.SetUserGrafanaAdminCtx(context.Background(), userID, isGrafanaAdmin)
+}
+
+func (gc *GrafanaClient_5_0) SetUserGrafanaAdminCtx(ctx context.Context, userID int, isGrafanaAdmin bool) error {
+	body := map[string]bool{"isGrafanaAdmin": isGrafanaAdmin}
+	return gc.callAPI(ctx, "PUT", fmt.Sprintf("/api/admin/users/%d/permissions", userID), body, nil, "SetUserGrafanaAdmin(api/admin/users/[USER-ID]/permissions)")
+}
+
+func (gc *GrafanaClient_5_0) DisableUser(userID int) error {
+	return gc.DisableUserCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) DisableUserCtx(ctx context.Context, userID int) error {
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/disable", userID), nil, nil, "DisableUser(api/admin/users/[USER-ID]/disable)")
+}
+
+func (gc *GrafanaClient_5_0) EnableUser(userID int) error {
+	return gc.EnableUserCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) EnableUserCtx(ctx context.Context, userID int) error {
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/enable", userID), nil, nil, "EnableUser(api/admin/users/[USER-ID]/enable)")
+}
+
+// LogoutUser 注销用户所有的登录会话
+func (gc *GrafanaClient_5_0) LogoutUser(userID int) error {
+	return gc.LogoutUserCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) LogoutUserCtx(ctx context.Context, userID int) error {
+	return gc.callAPI(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/logout", userID), nil, nil, "LogoutUser(api/admin/users/[USER-ID]/logout)")
+}
+
+func (gc *GrafanaClient_5_0) DeleteUser(userID int) error {
+	return gc.DeleteUserCtx(context.Background(), userID)
+}
+
+func (gc *GrafanaClient_5_0) DeleteUserCtx(ctx context.Context, userID int) error {
+	return gc.callAPI(ctx, "DELETE", fmt.Sprintf("/api/admin/users/%d", userID), nil, nil, "DeleteUser(api/admin/users/[USER-ID])")
+}
@@ -0,0 +1,348 @@
+package gografana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GrafanaClient_7_0 适用于Grafana 7.x及之后的版本。
+// 与5.x共用的API直接由内嵌的GrafanaClient_5_0提供，这里只实现新版本中新增或者发生变化的API。
+// 同一个Client可以被多个goroutine并发使用。
+type GrafanaClient_7_0 struct {
+	*GrafanaClient_5_0
+}
+
+// NewClient_7_0 创建一个适用于Grafana 7.x及之后版本的Client，opts与NewClient相同
+func NewClient_7_0(apiAddress string, opts ...Option) (*GrafanaClient_7_0, error) {
+	gc, err := NewClient(apiAddress, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GrafanaClient_7_0{GrafanaClient_5_0: gc}, nil
+}
+
+// WithOrg 与GrafanaClient_5_0.WithOrg相同，返回值可以断言为GrafanaClienter_7_0
+func (gc *GrafanaClient_7_0) WithOrg(orgID int) GrafanaClienter {
+	return &GrafanaClient_7_0{GrafanaClient_5_0: &GrafanaClient_5_0{baseClient: gc.withOrg(orgID)}}
+}
+
+func (gc *GrafanaClient_7_0) NewDashboardInFolder(board *Board, folderUid string, overwrite bool) (*Board, error) {
+	return gc.NewDashboardInFolderCtx(context.Background(), board, folderUid, overwrite)
+}
+
+func (gc *GrafanaClient_7_0) NewDashboardInFolderCtx(ctx context.Context, board *Board, folderUid string, overwrite bool) (*Board, error) {
+	return gc.SaveDashboardCtx(ctx, board, SaveDashboardOptions{FolderUID: folderUid, Overwrite: overwrite})
+}
+
+func (gc *GrafanaClient_7_0) GetAlertRules() ([]AlertRule, error) {
+	return gc.GetAlertRulesCtx(context.Background())
+}
+
+func (gc *GrafanaClient_7_0) GetAlertRulesCtx(ctx context.Context) ([]AlertRule, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/provisioning/alert-rules", gc.basicAddress), nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyData, err := gc.getHTTPResponse(req, "GetAlertRules(api/v1/provisioning/alert-rules)")
+	if err != nil {
+		return nil, err
+	}
+	var rules []AlertRule
+	err = json.Unmarshal(bodyData, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetAlertRules(api/v1/provisioning/alert-rules), error: %s", err.Error())
+	}
+	return rules, nil
+}
+
+func (gc *GrafanaClient_7_0) GetAlertRule(uid string) (*AlertRule, error) {
+	return gc.GetAlertRuleCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_7_0) GetAlertRuleCtx(ctx context.Context, uid string) (*AlertRule, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/provisioning/alert-rules/%s", gc.basicAddress, uid), nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyData, err := gc.getHTTPResponse(req, "GetAlertRule(api/v1/provisioning/alert-rules/[UID])")
+	if err != nil {
+		return nil, err
+	}
+	var rule AlertRule
+	err = json.Unmarshal(bodyData, &rule)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetAlertRule(api/v1/provisioning/alert-rules/[UID]), error: %s", err.Error())
+	}
+	return &rule, nil
+}
+
+// Status Codes:
+// -------------------
+// 201 – Created
+// 400 – Validation error
+func (gc *GrafanaClient_7_0) CreateAlertRule(rule *AlertRule) error {
+	return gc.CreateAlertRuleCtx(context.Background(), rule)
+}
+
+func (gc *GrafanaClient_7_0) CreateAlertRuleCtx(ctx context.Context, rule *AlertRule) error {
+	return gc.saveAlertRule(ctx, "POST", fmt.Sprintf("%s/api/v1/provisioning/alert-rules", gc.basicAddress), rule, "CreateAlertRule(api/v1/provisioning/alert-rules)")
+}
+
+func (gc *GrafanaClient_7_0) UpdateAlertRule(rule *AlertRule) error {
+	return gc.UpdateAlertRuleCtx(context.Background(), rule)
+}
+
+func (gc *GrafanaClient_7_0) UpdateAlertRuleCtx(ctx context.Context, rule *AlertRule) error {
+	return gc.saveAlertRule(ctx, "PUT", fmt.Sprintf("%s/api/v1/provisioning/alert-rules/%s", gc.basicAddress, rule.UID), rule, "UpdateAlertRule(api/v1/provisioning/alert-rules/[UID])")
+}
+
+func (gc *GrafanaClient_7_0) saveAlertRule(ctx context.Context, method, urlPath string, rule *AlertRule, flag string) error {
+	bodyStr, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlPath, strings.NewReader(string(bodyStr)))
+	if err != nil {
+		return err
+	}
+	rspBody, err := gc.getHTTPResponse(req, flag)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(rspBody, rule)
+	if err != nil {
+		return fmt.Errorf("Unmarshal response body failed while calling to API %s, error: %s", flag, err.Error())
+	}
+	return nil
+}
+
+// Status Codes:
+// -------------------
+// 204 – Deleted
+func (gc *GrafanaClient_7_0) DeleteAlertRule(uid string) error {
+	return gc.DeleteAlertRuleCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_7_0) DeleteAlertRuleCtx(ctx context.Context, uid string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/v1/provisioning/alert-rules/%s", gc.basicAddress, uid), nil)
+	if err != nil {
+		return err
+	}
+	_, err = gc.getHTTPResponse(req, "DeleteAlertRule(api/v1/provisioning/alert-rules/[UID])")
+	return err
+}
+
+func (gc *GrafanaClient_7_0) GetContactPoints() ([]ContactPoint, error) {
+	return gc.GetContactPointsCtx(context.Background())
+}
+
+func (gc *GrafanaClient_7_0) GetContactPointsCtx(ctx context.Context) ([]ContactPoint, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/provisioning/contact-points", gc.basicAddress), nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyData, err := gc.getHTTPResponse(req, "GetContactPoints(api/v1/provisioning/contact-points)")
+	if err != nil {
+		return nil, err
+	}
+	var points []ContactPoint
+	err = json.Unmarshal(bodyData, &points)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal response body failed while calling to API GetContactPoints(api/v1/provisioning/contact-points), error: %s", err.Error())
+	}
+	return points, nil
+}
+
+// Status Codes:
+// -------------------
+// 202 – Accepted
+// 400 – Validation error
+func (gc *GrafanaClient_7_0) CreateContactPoint(cp *ContactPoint) error {
+	return gc.CreateContactPointCtx(context.Background(), cp)
+}
+
+func (gc *GrafanaClient_7_0) CreateContactPointCtx(ctx context.Context, cp *ContactPoint) error {
+	bodyStr, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/provisioning/contact-points", gc.basicAddress), strings.NewReader(string(bodyStr)))
+	if err != nil {
+		return err
+	}
+	rspBody, err := gc.getHTTPResponse(req, "CreateContactPoint(api/v1/provisioning/contact-points)")
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(rspBody, cp)
+	if err != nil {
+		return fmt.Errorf("Unmarshal response body failed while calling to API CreateContactPoint(api/v1/provisioning/contact-points), error: %s", err.Error())
+	}
+	return nil
+}
+
+// 基于Dashboard UID的授权以及历史版本API从Grafana 9.0开始提供
+var uidDashboardAPIVersion = semVersion{9, 0, 0}
+
+// useUIDDashboardAPI 根据(缓存的)Grafana版本号判断是否使用基于UID的Dashboard API。
+// 版本号未知时返回fallback为true，调用方需要在基于UID的API返回404时退回到基于Dashboard ID的API。
+func (gc *GrafanaClient_7_0) useUIDDashboardAPI(ctx context.Context) (use, fallback bool) {
+	v, known := gc.serverVersion(ctx)
+	if !known {
+		gc.version.mu.Lock()
+		defer gc.version.mu.Unlock()
+		return !gc.version.noUIDDashboardAPI, true
+	}
+	return v.compare(uidDashboardAPIVersion) >= 0, false
+}
+
+// uidDashboardAPIMissing 在退回到基于ID的API并且调用成功后记录服务端不支持基于UID的Dashboard API，之后不再尝试
+func (gc *GrafanaClient_7_0) uidDashboardAPIMissing(err error) {
+	if err != nil {
+		return
+	}
+	gc.version.mu.Lock()
+	gc.version.noUIDDashboardAPI = true
+	gc.version.mu.Unlock()
+}
+
+// GetDashboardPermissions Grafana 9.0+使用基于UID的API获取Dashboard的授权，
+// 更早的版本使用基于Dashboard ID的API。
+func (gc *GrafanaClient_7_0) GetDashboardPermissions(uid string) ([]PermissionEntry, error) {
+	return gc.GetDashboardPermissionsCtx(context.Background(), uid)
+}
+
+func (gc *GrafanaClient_7_0) GetDashboardPermissionsCtx(ctx context.Context, uid string) ([]PermissionEntry, error) {
+	use, fallback := gc.useUIDDashboardAPI(ctx)
+	if !use {
+		return gc.GrafanaClient_5_0.GetDashboardPermissionsCtx(ctx, uid)
+	}
+	var entries []PermissionEntry
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/dashboards/uid/%s/permissions", url.PathEscape(uid)), nil, &entries, "GetDashboardPermissions(api/dashboards/uid/[UID]/permissions)")
+	if fallback && IsNotFound(err) {
+		entries, err = gc.GrafanaClient_5_0.GetDashboardPermissionsCtx(ctx, uid)
+		gc.uidDashboardAPIMissing(err)
+		return entries, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (gc *GrafanaClient_7_0) UpdateDashboardPermissions(uid string, items []PermissionItem) error {
+	return gc.UpdateDashboardPermissionsCtx(context.Background(), uid, items)
+}
+
+func (gc *GrafanaClient_7_0) UpdateDashboardPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) error {
+	use, fallback := gc.useUIDDashboardAPI(ctx)
+	if !use {
+		return gc.GrafanaClient_5_0.UpdateDashboardPermissionsCtx(ctx, uid, items)
+	}
+	if items == nil {
+		items = []PermissionItem{}
+	}
+	body := UpdatePermissionsRequest{Items: items}
+	err := gc.callAPI(ctx, "POST", fmt.Sprintf("/api/dashboards/uid/%s/permissions", url.PathEscape(uid)), body, nil, "UpdateDashboardPermissions(api/dashboards/uid/[UID]/permissions)")
+	if fallback && IsNotFound(err) {
+		err = gc.GrafanaClient_5_0.UpdateDashboardPermissionsCtx(ctx, uid, items)
+		gc.uidDashboardAPIMissing(err)
+	}
+	return err
+}
+
+func (gc *GrafanaClient_7_0) EnsureDashboardPermissions(uid string, items []PermissionItem) (bool, error) {
+	return gc.EnsureDashboardPermissionsCtx(context.Background(), uid, items)
+}
+
+func (gc *GrafanaClient_7_0) EnsureDashboardPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) (bool, error) {
+	if use, _ := gc.useUIDDashboardAPI(ctx); !use {
+		return gc.GrafanaClient_5_0.EnsureDashboardPermissionsCtx(ctx, uid, items)
+	}
+	entries, err := gc.GetDashboardPermissionsCtx(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	if permissionsEqual(ownPermissionItems(entries), items) {
+		return false, nil
+	}
+	err = gc.UpdateDashboardPermissionsCtx(ctx, uid, items)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListDashboardVersions Grafana 9.0+使用基于UID的API列举Dashboard的历史版本，
+// 更早的版本使用基于Dashboard ID的API。
+func (gc *GrafanaClient_7_0) ListDashboardVersions(uid string, limit, start int) ([]DashboardVersion, error) {
+	return gc.ListDashboardVersionsCtx(context.Background(), uid, limit, start)
+}
+
+func (gc *GrafanaClient_7_0) ListDashboardVersionsCtx(ctx context.Context, uid string, limit, start int) ([]DashboardVersion, error) {
+	use, fallback := gc.useUIDDashboardAPI(ctx)
+	if !use {
+		return gc.GrafanaClient_5_0.ListDashboardVersionsCtx(ctx, uid, limit, start)
+	}
+	var raw json.RawMessage
+	urlPath := fmt.Sprintf("/api/dashboards/uid/%s/versions", url.PathEscape(uid)) + dashboardVersionsQuery(limit, start)
+	err := gc.callAPI(ctx, "GET", urlPath, nil, &raw, "ListDashboardVersions(api/dashboards/uid/[UID]/versions)")
+	if fallback && IsNotFound(err) {
+		versions, err := gc.GrafanaClient_5_0.ListDashboardVersionsCtx(ctx, uid, limit, start)
+		gc.uidDashboardAPIMissing(err)
+		return versions, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseDashboardVersions(raw, "ListDashboardVersions(api/dashboards/uid/[UID]/versions)")
+}
+
+func (gc *GrafanaClient_7_0) GetDashboardVersion(uid string, version int) (*DashboardVersionDetail, error) {
+	return gc.GetDashboardVersionCtx(context.Background(), uid, version)
+}
+
+func (gc *GrafanaClient_7_0) GetDashboardVersionCtx(ctx context.Context, uid string, version int) (*DashboardVersionDetail, error) {
+	use, fallback := gc.useUIDDashboardAPI(ctx)
+	if !use {
+		return gc.GrafanaClient_5_0.GetDashboardVersionCtx(ctx, uid, version)
+	}
+	var detail DashboardVersionDetail
+	err := gc.callAPI(ctx, "GET", fmt.Sprintf("/api/dashboards/uid/%s/versions/%d", url.PathEscape(uid), version), nil, &detail, "GetDashboardVersion(api/dashboards/uid/[UID]/versions/[VERSION])")
+	if fallback && IsNotFound(err) {
+		fallbackDetail, err := gc.GrafanaClient_5_0.GetDashboardVersionCtx(ctx, uid, version)
+		gc.uidDashboardAPIMissing(err)
+		return fallbackDetail, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+func (gc *GrafanaClient_7_0) RestoreDashboardVersion(uid string, version int) (*CreateDashboardResponse, error) {
+	return gc.RestoreDashboardVersionCtx(context.Background(), uid, version)
+}
+
+func (gc *GrafanaClient_7_0) RestoreDashboardVersionCtx(ctx context.Context, uid string, version int) (*CreateDashboardResponse, error) {
+	use, fallback := gc.useUIDDashboardAPI(ctx)
+	if !use {
+		return gc.GrafanaClient_5_0.RestoreDashboardVersionCtx(ctx, uid, version)
+	}
+	var rsp CreateDashboardResponse
+	body := RestoreDashboardVersionRequest{Version: version}
+	err := gc.callAPI(ctx, "POST", fmt.Sprintf("/api/dashboards/uid/%s/restore", url.PathEscape(uid)), body, &rsp, "RestoreDashboardVersion(api/dashboards/uid/[UID]/restore)")
+	if fallback && IsNotFound(err) {
+		fallbackRsp, err := gc.GrafanaClient_5_0.RestoreDashboardVersionCtx(ctx, uid, version)
+		gc.uidDashboardAPIMissing(err)
+		return fallbackRsp, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
@@ -0,0 +1,149 @@
+package gografana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Grafana的/api/folders每页最多返回1000个Folder
+const foldersPerPage = 1000
+
+// nested folders(parentUid参数)从Grafana 10.0开始提供，更早的版本会忽略parentUid而总是返回顶层的Folder
+var nestedFoldersVersion = semVersion{10, 0, 0}
+
+// checkNestedFolders 在(缓存的)Grafana版本号已知并且低于10.0时返回ErrNestedFoldersUnsupported
+func (gc *GrafanaClient_7_0) checkNestedFolders(ctx context.Context) error {
+	if v, known := gc.serverVersion(ctx); known && v.compare(nestedFoldersVersion) < 0 {
+		return fmt.Errorf("%w: Grafana %d.%d.%d", ErrNestedFoldersUnsupported, v.major, v.minor, v.patch)
+	}
+	return nil
+}
+
+// GetFolderChildren 列举parentUID下的直接子Folder，parentUID为空时列举顶层的Folder。
+// parentUID不为空时需要Grafana 10+开启nested folders，否则返回的错误满足errors.Is(err, ErrNestedFoldersUnsupported)。
+func (gc *GrafanaClient_7_0) GetFolderChildren(parentUID string) ([]Folder, error) {
+	return gc.GetFolderChildrenCtx(context.Background(), parentUID)
+}
+
+func (gc *GrafanaClient_7_0) GetFolderChildrenCtx(ctx context.Context, parentUID string) ([]Folder, error) {
+	if parentUID != "" {
+		if err := gc.checkNestedFolders(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var folders []Folder
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("limit", fmt.Sprintf("%d", foldersPerPage))
+		params.Set("page", fmt.Sprintf("%d", page))
+		if parentUID != "" {
+			params.Set("parentUid", parentUID)
+		}
+		var pageFolders []Folder
+		err := gc.callAPI(ctx, "GET", "/api/folders?"+params.Encode(), nil, &pageFolders, "GetFolderChildren(api/folders?parentUid=)")
+		if err != nil {
+			return nil, err
+		}
+		//不支持page参数的旧版本Grafana总是返回第一页
+		if page > 1 && len(pageFolders) > 0 && pageFolders[0].UID == folders[0].UID {
+			return folders, nil
+		}
+		//版本号未知时，通过返回的parentUid识别忽略了parentUid参数的旧版本Grafana
+		for _, f := range pageFolders {
+			if f.ParentUID != parentUID {
+				return nil, fmt.Errorf("%w: folder %q returned as a child of %q has parentUid %q", ErrNestedFoldersUnsupported, f.UID, parentUID, f.ParentUID)
+			}
+		}
+		folders = append(folders, pageFolders...)
+		if len(pageFolders) < foldersPerPage {
+			return folders, nil
+		}
+	}
+}
+
+// MoveFolder 将Folder移动到newParentUID下，newParentUID为空时移动到顶层
+func (gc *GrafanaClient_7_0) MoveFolder(uid, newParentUID string) (*Folder, error) {
+	return gc.MoveFolderCtx(context.Background(), uid, newParentUID)
+}
+
+func (gc *GrafanaClient_7_0) MoveFolderCtx(ctx context.Context, uid, newParentUID string) (*Folder, error) {
+	var folder Folder
+	body := MoveFolderRequest{ParentUID: newParentUID}
+	err := gc.callAPI(ctx, "POST", fmt.Sprintf("/api/folders/%s/move", url.PathEscape(uid)), body, &folder, "MoveFolder(api/folders/[UID]/move)")
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// GetFolderByPath 按照以"/"分隔的Folder标题路径(例如"team/service/prod")从顶层逐级查找Folder，
+// 任意一级不存在时返回的错误满足IsNotFound。多级路径需要Grafana 10+开启nested folders，否则返回ErrNestedFoldersUnsupported。
+func (gc *GrafanaClient_7_0) GetFolderByPath(path string) (*Folder, error) {
+	return gc.GetFolderByPathCtx(context.Background(), path)
+}
+
+func (gc *GrafanaClient_7_0) GetFolderByPathCtx(ctx context.Context, path string) (*Folder, error) {
+	return gc.resolveFolderPath(ctx, path, false)
+}
+
+// EnsureFolderPath 与GetFolderByPath相同，但会依次创建路径中不存在的Folder，返回路径中最后一级的Folder
+func (gc *GrafanaClient_7_0) EnsureFolderPath(path string) (*Folder, error) {
+	return gc.EnsureFolderPathCtx(context.Background(), path)
+}
+
+func (gc *GrafanaClient_7_0) EnsureFolderPathCtx(ctx context.Context, path string) (*Folder, error) {
+	return gc.resolveFolderPath(ctx, path, true)
+}
+
+func (gc *GrafanaClient_7_0) resolveFolderPath(ctx context.Context, path string, create bool) (*Folder, error) {
+	titles := splitFolderPath(path)
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("invalid folder path %q", path)
+	}
+	if len(titles) > 1 {
+		if err := gc.checkNestedFolders(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var current *Folder
+	for i, title := range titles {
+		parentUID := ""
+		if current != nil {
+			parentUID = current.UID
+		}
+		children, err := gc.GetFolderChildrenCtx(ctx, parentUID)
+		if err != nil {
+			return nil, err
+		}
+		var found *Folder
+		for j := range children {
+			if children[j].Title == title {
+				found = &children[j]
+				break
+			}
+		}
+		if found == nil {
+			if !create {
+				return nil, fmt.Errorf("%w: %s", ErrFolderNotFound, strings.Join(titles[:i+1], "/"))
+			}
+			found = &Folder{Title: title, ParentUID: parentUID}
+			if err = gc.CreateFolderCtx(ctx, found); err != nil {
+				return nil, err
+			}
+		}
+		current = found
+	}
+	return current, nil
+}
+
+func splitFolderPath(path string) []string {
+	var titles []string
+	for _, title := range strings.Split(path, "/") {
+		if title = strings.TrimSpace(title); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
@@ -0,0 +1,187 @@
+package gografana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+// baseClient 封装了各个版本Client共用的HTTP调用逻辑(授权、代理、TLS以及响应处理)，
+// 不同版本的Client只需要关心各自API的路径和数据结构。
+// baseClient的字段都在创建时初始化完成，之后只读(统计信息通过atomic更新，version指向的缓存由其内部的锁保护)，因此可以被多个goroutine并发使用。
+type baseClient struct {
+	basicAddress  string
+	client        *http.Client
+	authenticator Authenticator
+	options       *clientOptions
+	rateLimiter   *tokenBucket
+	inFlight      chan struct{}
+	stats         *limiterStats
+	//大于0时通过X-Grafana-Org-Id将请求限定在指定的Organization中
+	orgID int
+	//远程Grafana的版本号，由WithServerVersion设置或者在第一次需要时探测
+	version *versionCache
+}
+
+func newBaseClient(apiAddress string, opts ...Option) (*baseClient, error) {
+	options, err := newClientOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	bc := &baseClient{
+		basicAddress:  apiAddress,
+		client:        options.newHTTPClient(),
+		authenticator: options.authenticator,
+		options:       options,
+		stats:         &limiterStats{},
+		version:       &versionCache{},
+	}
+	if options.serverVersion != nil {
+		bc.version.version, bc.version.known, bc.version.detected = *options.serverVersion, true, true
+	}
+	if options.rateLimit > 0 {
+		bc.rateLimiter = newTokenBucket(options.rateLimit, options.rateBurst)
+	}
+	if options.maxInFlight > 0 {
+		bc.inFlight = make(chan struct{}, options.maxInFlight)
+	}
+	return bc, nil
+}
+
+// withOrg 返回一个限定在orgID中的副本，副本与原Client共享http.Client、限流器、统计信息以及版本号
+func (bc *baseClient) withOrg(orgID int) *baseClient {
+	scoped := *bc
+	scoped.orgID = orgID
+	return &scoped
+}
+
+// callAPI 以JSON的形式发送body(为nil时不发送请求体)，并将响应反序列化到out中(为nil时忽略响应体)，
+// urlPath是相对于Grafana地址的路径，例如"/api/orgs/1"
+func (bc *baseClient) callAPI(ctx context.Context, method, urlPath string, body, out interface{}, flag string) error {
+	var reqBody io.Reader
+	if body != nil {
+		bodyStr, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bodyStr)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, bc.basicAddress+urlPath, reqBody)
+	if err != nil {
+		return err
+	}
+	rspBody, err := bc.getHTTPResponse(req, flag)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	err = json.Unmarshal(rspBody, out)
+	if err != nil {
+		return fmt.Errorf("Unmarshal response body failed while calling to API %s, error: %s", flag, err.Error())
+	}
+	return nil
+}
+
+func (bc *baseClient) getHTTPResponse(req *http.Request, flag string) ([]byte, error) {
+	bodyData, statusCode, err := bc.doRequest(req, flag)
+	if err != nil {
+		return nil, err
+	}
+	if !isSuccessStatusCode(statusCode) {
+		return nil, newAPIError(req, flag, statusCode, bodyData)
+	}
+	return bodyData, nil
+}
+
+func (bc *baseClient) doRequest(req *http.Request, flag string) ([]byte, int, error) {
+	for key, values := range bc.options.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if bc.orgID > 0 {
+		req.Header.Set("X-Grafana-Org-Id", strconv.Itoa(bc.orgID))
+	}
+	if bc.options.userAgent != "" {
+		req.Header.Set("User-Agent", bc.options.userAgent)
+	}
+	//加入统一授权
+	if bc.authenticator != nil {
+		bc.authenticator.SetAuthentication(req)
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	event := bc.newRequestEvent(req, flag)
+	ctx := req.Context()
+	for _, h := range bc.options.hooks {
+		if h.BeforeRequest != nil {
+			ctx = h.BeforeRequest(ctx, event)
+		}
+	}
+	req = event.Request.WithContext(ctx)
+	bodyData, statusCode, attempts, err := bc.doWithRetry(req, flag)
+	bc.finishRequestEvent(ctx, event, bodyData, statusCode, attempts, err)
+	return bodyData, statusCode, err
+}
+
+func (bc *baseClient) doWithRetry(req *http.Request, flag string) ([]byte, int, int, error) {
+	policy := bc.options.retryPolicy
+	retryable := policy.allows(req)
+	for attempt := 1; ; attempt++ {
+		bodyData, statusCode, header, err := bc.roundTrip(req, flag)
+		if !retryable || attempt >= policy.MaxAttempts || req.Context().Err() != nil {
+			return bodyData, statusCode, attempt, err
+		}
+		if err != nil && !isRetryableError(err) {
+			return bodyData, statusCode, attempt, err
+		}
+		if err == nil && !isRetryableStatusCode(statusCode) {
+			return bodyData, statusCode, attempt, err
+		}
+		wait, ok := policy.backoff(attempt, header)
+		if !ok {
+			return bodyData, statusCode, attempt, err
+		}
+		if sleepErr := sleepContext(req.Context(), wait); sleepErr != nil {
+			return bodyData, statusCode, attempt, err
+		}
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return bodyData, statusCode, attempt, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (bc *baseClient) roundTrip(req *http.Request, flag string) ([]byte, int, http.Header, error) {
+	release, err := bc.acquire(req.Context())
+	if err != nil {
+		return nil, -1, nil, err
+	}
+	defer release()
+	rsp, err := bc.client.Do(req)
+	if err != nil {
+		return nil, -1, nil, err
+	}
+	defer rsp.Body.Close()
+	bodyData, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, -1, nil, fmt.Errorf("Read response body failed while calling to API %s, error: %w", flag, err)
+	}
+	return bodyData, rsp.StatusCode, rsp.Header, nil
+}
+
+// Grafana较新的API(例如Alerting Provisioning)会返回201/202/204等状态码
+func isSuccessStatusCode(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
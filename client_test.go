@@ -0,0 +1,327 @@
+package gografana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 返回JSON数组的GET接口(按路径后缀匹配)，其余接口返回"{}"
+var listPathSuffixes = []string{
+	"/api/search", "/api/folders", "/api/orgs", "/users", "/teams", "/orgs", "/members",
+	"/permissions", "/versions", "/api/datasources", "/api/alert-notifications",
+	"/alert-rules", "/contact-points",
+}
+
+// newStressServer 模拟一个Grafana，每隔几个请求返回一次503以触发重试
+func newStressServer(t *testing.T) (*httptest.Server, *int64) {
+	var requests int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if n%7 == 0 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message":"rolling update"}`))
+			return
+		}
+		if r.URL.Path == "/api/health" {
+			w.Write([]byte(`{"database":"ok","version":"9.3.2"}`))
+			return
+		}
+		if r.Method == "GET" {
+			for _, suffix := range listPathSuffixes {
+				if strings.HasSuffix(r.URL.Path, suffix) {
+					w.Write([]byte(`[]`))
+					return
+				}
+			}
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+type hookCounters struct {
+	before, after, errors int64
+}
+
+func stressOptions(counters *hookCounters) []Option {
+	return []Option{
+		WithAuthenticator(NewBasicAuthenticator("admin", "admin")),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2, Jitter: 0.5}),
+		WithRateLimit(5000, 50),
+		WithMaxInFlight(4),
+		WithBodyCapture(RedactJSONFields(DefaultRedactedFields...)),
+		WithHooks(Hooks{
+			BeforeRequest: func(ctx context.Context, e *RequestEvent) context.Context {
+				atomic.AddInt64(&counters.before, 1)
+				return ctx
+			},
+			AfterResponse: func(ctx context.Context, e *RequestEvent) {
+				atomic.AddInt64(&counters.after, 1)
+			},
+			OnError: func(ctx context.Context, e *RequestEvent) {
+				atomic.AddInt64(&counters.errors, 1)
+			},
+		}),
+	}
+}
+
+// stressArgs 为method构造参数，每次调用都会重新构造，避免测试本身在参数上产生竞争
+func stressArgs(ctx context.Context, method reflect.Type) []reflect.Value {
+	args := make([]reflect.Value, method.NumIn())
+	for i := range args {
+		in := method.In(i)
+		switch {
+		case in == reflect.TypeOf((*context.Context)(nil)).Elem():
+			args[i] = reflect.ValueOf(ctx)
+		case in.Kind() == reflect.String:
+			args[i] = reflect.ValueOf("uid-1").Convert(in)
+		case in.Kind() == reflect.Int || in.Kind() == reflect.Uint:
+			args[i] = reflect.ValueOf(1).Convert(in)
+		case in.Kind() == reflect.Ptr:
+			args[i] = reflect.New(in.Elem())
+		default:
+			args[i] = reflect.Zero(in)
+		}
+	}
+	return args
+}
+
+// hammer 在多个goroutine中并发调用iface中的每一个方法
+func hammer(t *testing.T, client interface{}, iface reflect.Type) {
+	const goroutines = 8
+	value := reflect.ValueOf(client)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iface.NumMethod(); i++ {
+				name := iface.Method(i).Name
+				method := value.MethodByName(name)
+				results := method.Call(stressArgs(ctx, method.Type()))
+				for _, result := range results {
+					switch r := result.Interface().(type) {
+					case *SearchIterator:
+						for r.Next() {
+						}
+					case GrafanaClienter:
+						r.LimiterStats()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		t.Error("concurrent calls did not finish in time")
+	}
+}
+
+func TestGrafanaClient_5_0ConcurrentUse(t *testing.T) {
+	srv, requests := newStressServer(t)
+	var counters hookCounters
+	client, err := NewClient(srv.URL, stressOptions(&counters)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iface := reflect.TypeOf((*GrafanaClienter)(nil)).Elem()
+	var wg sync.WaitGroup
+	for _, c := range []GrafanaClienter{client, client.WithOrg(2)} {
+		wg.Add(1)
+		go func(c GrafanaClienter) {
+			defer wg.Done()
+			hammer(t, c, iface)
+		}(c)
+	}
+	wg.Wait()
+	assertStressCounters(t, client, atomic.LoadInt64(requests), &counters)
+}
+
+func TestGrafanaClient_7_0ConcurrentUse(t *testing.T) {
+	srv, requests := newStressServer(t)
+	var counters hookCounters
+	client, err := NewClient_7_0(srv.URL, stressOptions(&counters)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iface := reflect.TypeOf((*GrafanaClienter_7_0)(nil)).Elem()
+	var wg sync.WaitGroup
+	for _, c := range []GrafanaClienter{client, client.WithOrg(2)} {
+		wg.Add(1)
+		go func(c GrafanaClienter) {
+			defer wg.Done()
+			hammer(t, c, iface)
+		}(c)
+	}
+	wg.Wait()
+	assertStressCounters(t, client, atomic.LoadInt64(requests), &counters)
+}
+
+func assertStressCounters(t *testing.T, client GrafanaClienter, requests int64, counters *hookCounters) {
+	t.Helper()
+	if requests == 0 {
+		t.Fatal("no request reached the server")
+	}
+	if atomic.LoadInt64(&counters.before) == 0 || atomic.LoadInt64(&counters.after) == 0 {
+		t.Errorf("hooks were not called: %+v", counters)
+	}
+	if stats := client.LimiterStats(); stats.InFlight != 0 {
+		t.Errorf("requests still in flight after all calls returned: %+v", stats)
+	}
+}
+
+// 版本探测阻塞时，其余调用应在各自的ctx结束时返回，而不是等待探测完成
+func TestServerVersionWaitersHonorContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"database":"ok","version":"9.3.2"}`))
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	client, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go client.serverVersion(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, known := client.serverVersion(ctx); known {
+		t.Error("expected unknown version while detection is still running")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("serverVersion ignored its context, returned after %v", elapsed)
+	}
+}
+
+// 不支持nested folders的Grafana会忽略parentUid，总是返回顶层的Folder
+func TestFolderPathRequiresNestedFolders(t *testing.T) {
+	for _, health := range []string{`{"version":"9.5.1"}`, `{}`} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/health":
+				w.Write([]byte(health))
+			case "/api/folders":
+				w.Write([]byte(`[{"uid":"a","title":"team"},{"uid":"b","title":"prod"}]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"not found"}`))
+			}
+		}))
+		client, err := NewClient_7_0(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.GetFolderByPath("team/prod")
+		if !errors.Is(err, ErrNestedFoldersUnsupported) {
+			t.Errorf("health %s: expected ErrNestedFoldersUnsupported, got %v", health, err)
+		}
+		srv.Close()
+	}
+}
+
+// 不支持page参数的Grafana总是返回第一页，GetFolderChildren不能因此无限翻页
+func TestGetFolderChildrenIgnoredPage(t *testing.T) {
+	page := make([]Folder, foldersPerPage)
+	for i := range page {
+		page[i] = Folder{UID: fmt.Sprintf("f%d", i), Title: fmt.Sprintf("folder %d", i)}
+	}
+	body, _ := json.Marshal(page)
+	var requests int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := NewClient_7_0(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	folders, err := client.GetFolderChildren("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != foldersPerPage || atomic.LoadInt64(&requests) != 2 {
+		t.Errorf("expected %d folders from 2 requests, got %d from %d", foldersPerPage, len(folders), requests)
+	}
+}
+
+// 默认不读取HTTP_PROXY等环境变量，只有WithProxyFromEnvironment时才使用
+func TestProxyFromEnvironmentIsOptIn(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.local:3128")
+	req, _ := http.NewRequest("GET", "https://grafana.example.com/api/health", nil)
+	cases := []struct {
+		opts  []Option
+		proxy string
+	}{
+		{nil, ""},
+		{[]Option{WithProxy("")}, ""},
+		{[]Option{WithProxyFromEnvironment()}, "http://env-proxy.local:3128"},
+		{[]Option{WithProxyFromEnvironment(), WithProxy("http://proxy.local:8080")}, "http://proxy.local:8080"},
+	}
+	for i, c := range cases {
+		o, err := newClientOptions(c.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr := o.newHTTPClient().Transport.(*http.Transport)
+		var got string
+		if tr.Proxy != nil {
+			u, err := tr.Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u != nil {
+				got = u.String()
+			}
+		}
+		if got != c.proxy {
+			t.Errorf("case %d: expected proxy %q, got %q", i, c.proxy, got)
+		}
+	}
+}
+
+// Retry-After超过MaxBackoff时不再重试，直接返回错误
+func TestRetryAfterBeyondMaxBackoff(t *testing.T) {
+	var requests int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"message":"maintenance"}`))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := NewClient(srv.URL, WithRetryPolicy(DefaultRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = client.GetAllFolders()
+	if !hasStatusCode(err, http.StatusServiceUnavailable) {
+		t.Errorf("expected 503 error, got %v", err)
+	}
+	if n := atomic.LoadInt64(&requests); n != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("expected a single request without waiting, got %d requests in %v", n, time.Since(start))
+	}
+}
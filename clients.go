@@ -1,39 +1,163 @@
 package gografana
 
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ClientFactory 根据Grafana的地址以及选项(授权方式、代理、TLS配置等)创建一个Client
+type ClientFactory func(apiAddress string, opts ...Option) (GrafanaClienter, error)
+
+type clientRegistration struct {
+	constraint string
+	versions   versionConstraint
+	factory    ClientFactory
+}
+
 var (
-	clients map[string]func(string, string, Authenticator) GrafanaClienter
+	clientsLock sync.RWMutex
+	clients     []*clientRegistration
 )
 
-//根据Grafana的版本号来获取指定的Client
-func GetClientByVersion(version, apiAddress string, auth Authenticator) (GrafanaClienter, error) {
-	if v, ok := clients[version]; ok {
-		return v(apiAddress, "", auth), nil
+// RegisterClient 注册一个用于指定Grafana版本范围的Client，可以在任意goroutine中调用。
+// versionConstraint支持"5.x"、">=8.0 <9.0"、"~8.2"、"5.x || 6.x"这类写法，
+// 当一个版本同时满足多个Client时，后注册的Client优先，从而可以覆盖内置的实现。
+// 使用相同的versionConstraint重复注册会替换之前的Client。
+func RegisterClient(versionConstraint string, factory ClientFactory) error {
+	if factory == nil {
+		return fmt.Errorf("RegisterClient: nil factory for version constraint %q", versionConstraint)
+	}
+	versions, err := parseConstraint(versionConstraint)
+	if err != nil {
+		return err
 	}
-	return nil, ErrNoSpecifiedVerClient{}
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+	for i, r := range clients {
+		if r.constraint == versionConstraint {
+			clients = append(clients[:i], clients[i+1:]...)
+			break
+		}
+	}
+	clients = append(clients, &clientRegistration{constraint: versionConstraint, versions: versions, factory: factory})
+	return nil
+}
+
+// RegisteredVersions 按注册顺序返回所有已注册Client的版本范围
+func RegisteredVersions() []string {
+	clientsLock.RLock()
+	defer clientsLock.RUnlock()
+	versions := make([]string, 0, len(clients))
+	for _, r := range clients {
+		versions = append(versions, r.constraint)
+	}
+	return versions
+}
+
+// findClientFactory 优先按照注册时使用的版本范围字符串精确匹配(例如"5.x")，
+// 否则将version作为版本号，选择最后注册的满足该版本的Client。
+func findClientFactory(version string) (ClientFactory, error) {
+	clientsLock.RLock()
+	defer clientsLock.RUnlock()
+	for _, r := range clients {
+		if r.constraint == version {
+			return r.factory, nil
+		}
+	}
+	v, err := parseVersion(version)
+	if err != nil {
+		return nil, newErrNoSpecifiedVerClient(version, clients)
+	}
+	for i := len(clients) - 1; i >= 0; i-- {
+		if clients[i].versions.check(v) {
+			return clients[i].factory, nil
+		}
+	}
+	return nil, newErrNoSpecifiedVerClient(version, clients)
+}
+
+//根据Grafana的版本号来获取指定的Client，版本号既可以是注册时的版本范围(例如"5.x")，也可以是具体的版本号(例如"8.5.3")
+func GetClientByVersion(version, apiAddress string, auth Authenticator, opts ...Option) (GrafanaClienter, error) {
+	factory, err := findClientFactory(version)
+	if err != nil {
+		return nil, err
+	}
+	baseOpts := []Option{WithAuthenticator(auth)}
+	if _, specified, err := parseVersionParts(version); err == nil && specified == 3 {
+		baseOpts = append(baseOpts, WithServerVersion(version))
+	}
+	return factory(apiAddress, append(baseOpts, opts...)...)
 }
 
 //根据Grafana的版本号来获取指定的Client，并设置 http proxy
 func GetClientByVersionWithProxy(version, apiAddress, httpProxy string, auth Authenticator) (GrafanaClienter, error) {
-	if v, ok := clients[version]; ok {
-		return v(apiAddress, httpProxy, auth), nil
+	return GetClientByVersion(version, apiAddress, auth, WithProxy(httpProxy))
+}
+
+// 自动探测远程Grafana的版本号，并从已注册的Client中选择最合适的一个
+func GetClient(apiAddress string, auth Authenticator, opts ...Option) (GrafanaClienter, error) {
+	return GetClientCtx(context.Background(), apiAddress, auth, opts...)
+}
+
+// 自动探测远程Grafana的版本号，并从已注册的Client中选择最合适的一个，并设置 http proxy
+func GetClientWithProxy(apiAddress, httpProxy string, auth Authenticator) (GrafanaClienter, error) {
+	return GetClientCtx(context.Background(), apiAddress, auth, WithProxy(httpProxy))
+}
+
+// 与GetClientWithProxy相同，版本探测请求受ctx控制
+func GetClientWithProxyCtx(ctx context.Context, apiAddress, httpProxy string, auth Authenticator) (GrafanaClienter, error) {
+	return GetClientCtx(ctx, apiAddress, auth, WithProxy(httpProxy))
+}
+
+// 与GetClient相同，版本探测请求受ctx控制
+func GetClientCtx(ctx context.Context, apiAddress string, auth Authenticator, opts ...Option) (GrafanaClienter, error) {
+	detector, err := NewClient(apiAddress, append([]Option{WithAuthenticator(auth)}, opts...)...)
+	if err != nil {
+		return nil, err
 	}
-	return nil, ErrNoSpecifiedVerClient{}
+	version, err := detector.GetGrafanaVersionCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return GetClientByVersion(version, apiAddress, auth, opts...)
 }
 
 func init() {
-	clients = make(map[string]func(string, string, Authenticator) GrafanaClienter)
-	clients["5.x"] = func(apiAddress string, httpProxy string, auth Authenticator) GrafanaClienter {
-		return &GrafanaClient_5_0{basicAddress: apiAddress, authenticator: auth, httpProxy: httpProxy}
+	//Grafana 6.x上5.x的API仍然可以正常工作
+	mustRegisterClient("5.x || 6.x", func(apiAddress string, opts ...Option) (GrafanaClienter, error) {
+		gc, err := NewClient(apiAddress, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return gc, nil
+	})
+	mustRegisterClient(">=7.0", func(apiAddress string, opts ...Option) (GrafanaClienter, error) {
+		gc, err := NewClient_7_0(apiAddress, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return gc, nil
+	})
+}
+
+func mustRegisterClient(versionConstraint string, factory ClientFactory) {
+	if err := RegisterClient(versionConstraint, factory); err != nil {
+		panic(err)
 	}
 }
 
+// GrafanaClienter 中的每一个方法都有一个以Ctx结尾的版本，用于传入context.Context以支持取消和超时控制。
+// 不带Ctx的方法等价于使用context.Background()调用对应的Ctx方法。
+// 本包提供的所有实现都可以被多个goroutine并发使用。
 type GrafanaClienter interface {
-	GetAllDashboards() ([]Board, error)
+	GetAllDashboards() ([]SearchHit, error)
 	GetAllFolders() ([]Folder, error)
-	GetDashboardsByTitleAndFolderId(title string, folderId int) ([]Board, error)
-	GetDashboardsByFolderId(folderId int) ([]Board, error)
-	IsBoardExists(title string) (bool, *Board, error)
+	GetDashboardsByTitleAndFolderId(title string, folderId int) ([]SearchHit, error)
+	GetDashboardsByFolderId(folderId int) ([]SearchHit, error)
+	IsBoardExists(title string) (bool, *SearchHit, error)
 	NewDashboard(board *Board, folderId uint, overwrite bool) (*Board, error)
+	SaveDashboard(board *Board, opts SaveDashboardOptions) (*Board, error)
 	DeleteDashboard(uid string) (bool, error)
 	GetDashboardDetails(uid string) (*Board, error)
 	EnsureFolderExists(folderId int, uid, title string) (int, bool, error)
@@ -48,4 +172,195 @@ type GrafanaClienter interface {
 	//NOTIFICATIONS
 	GetAllNotificationChannels() ([]NotificationChannel, error)
 	CreateNotificationChannel(nc *NotificationChannel) error
+	//LIMITER
+	LimiterStats() LimiterStats
+	//ORGANIZATION
+	WithOrg(orgID int) GrafanaClienter
+	SwitchUserOrg(orgID int) error
+	GetAllOrgs() ([]Org, error)
+	GetOrgByID(orgID int) (*Org, error)
+	GetOrgByName(name string) (*Org, error)
+	CreateOrg(org *Org) error
+	UpdateOrg(org *Org) error
+	UpdateOrgAddress(orgID int, address OrgAddress) error
+	DeleteOrg(orgID int) error
+	GetOrgUsers(orgID int) ([]OrgUser, error)
+	AddOrgUser(orgID int, loginOrEmail, role string) error
+	UpdateOrgUserRole(orgID, userID int, role string) error
+	RemoveOrgUser(orgID, userID int) error
+	GetOrgPreferences() (*Preferences, error)
+	UpdateOrgPreferences(prefs *Preferences) error
+	//USERS
+	SearchUsers(query string, perPage, page int) (*SearchUsersResponse, error)
+	GetUserByID(userID int) (*User, error)
+	GetUserByLoginOrEmail(loginOrEmail string) (*User, error)
+	UpdateUser(user *User) error
+	GetUserOrgs(userID int) ([]UserOrg, error)
+	GetUserTeams(userID int) ([]Team, error)
+	CreateUser(user *User, password string) error
+	SetUserPassword(userID int, password string) error
+	SetUserGrafanaAdmin(userID int, isGrafanaAdmin bool) error
+	DisableUser(userID int) error
+	EnableUser(userID int) error
+	LogoutUser(userID int) error
+	DeleteUser(userID int) error
+
+	//TEAMS
+	SearchTeams(query string, perPage, page int) (*SearchTeamsResponse, error)
+	GetTeamByID(teamID int) (*Team, error)
+	CreateTeam(team *Team) error
+	UpdateTeam(team *Team) error
+	DeleteTeam(teamID int) error
+	GetTeamMembers(teamID int) ([]TeamMember, error)
+	AddTeamMember(teamID, userID int) error
+	RemoveTeamMember(teamID, userID int) error
+	SetTeamMembers(teamID int, userIDs []int) error
+	GetTeamPreferences(teamID int) (*Preferences, error)
+	UpdateTeamPreferences(teamID int, prefs *Preferences) error
+
+	//FOLDER PERMISSIONS
+	GetFolderPermissions(uid string) ([]PermissionEntry, error)
+	UpdateFolderPermissions(uid string, items []PermissionItem) error
+	EnsureFolderPermissions(uid string, items []PermissionItem) (bool, error)
+
+	//DASHBOARD PERMISSIONS
+	GetDashboardPermissions(uid string) ([]PermissionEntry, error)
+	GetDashboardPermissionsByID(dashboardID int) ([]PermissionEntry, error)
+	UpdateDashboardPermissions(uid string, items []PermissionItem) error
+	UpdateDashboardPermissionsByID(dashboardID int, items []PermissionItem) error
+	EnsureDashboardPermissions(uid string, items []PermissionItem) (bool, error)
+
+	//FOLDERS
+	GetFolderByUID(uid string) (*Folder, error)
+	GetFolderByID(folderId int) (*Folder, error)
+	CreateFolder(folder *Folder) error
+	UpdateFolder(folder *Folder, overwrite bool) error
+	DeleteFolder(uid string, forceDeleteRules bool) error
+	EnsureFolder(uid, title string) (*Folder, bool, error)
+
+	//DASHBOARD VERSIONS
+	ListDashboardVersions(uid string, limit, start int) ([]DashboardVersion, error)
+	GetDashboardVersion(uid string, version int) (*DashboardVersionDetail, error)
+	RestoreDashboardVersion(uid string, version int) (*CreateDashboardResponse, error)
+
+	//SEARCH
+	Search(query SearchQuery) ([]SearchHit, error)
+	SearchIter(query SearchQuery) *SearchIterator
+	GetDashboardForHit(hit SearchHit) (*Board, error)
+
+	//CONTEXT
+	GetAllDashboardsCtx(ctx context.Context) ([]SearchHit, error)
+	GetAllFoldersCtx(ctx context.Context) ([]Folder, error)
+	GetDashboardsByTitleAndFolderIdCtx(ctx context.Context, title string, folderId int) ([]SearchHit, error)
+	GetDashboardsByFolderIdCtx(ctx context.Context, folderId int) ([]SearchHit, error)
+	IsBoardExistsCtx(ctx context.Context, title string) (bool, *SearchHit, error)
+	NewDashboardCtx(ctx context.Context, board *Board, folderId uint, overwrite bool) (*Board, error)
+	SaveDashboardCtx(ctx context.Context, board *Board, opts SaveDashboardOptions) (*Board, error)
+	DeleteDashboardCtx(ctx context.Context, uid string) (bool, error)
+	GetDashboardDetailsCtx(ctx context.Context, uid string) (*Board, error)
+	EnsureFolderExistsCtx(ctx context.Context, folderId int, uid, title string) (int, bool, error)
+	CreateAPIKeyCtx(ctx context.Context, name string, role string, secondsToLive int) (string, error)
+	FindAllAPIKeysCtx(ctx context.Context) ([]APIKey, error)
+	DeleteAPIKeyCtx(ctx context.Context, id int) (bool, error)
+	GetAllDataSourcesCtx(ctx context.Context) ([]*DataSource, error)
+	GetDashSourceByIdCtx(ctx context.Context, id int) (*DataSource, error)
+	DeleteDashSourceCtx(ctx context.Context, id int) error
+	CreateDashSourceCtx(ctx context.Context, ds *DataSource) error
+	GetAllNotificationChannelsCtx(ctx context.Context) ([]NotificationChannel, error)
+	CreateNotificationChannelCtx(ctx context.Context, nc *NotificationChannel) error
+	SwitchUserOrgCtx(ctx context.Context, orgID int) error
+	GetAllOrgsCtx(ctx context.Context) ([]Org, error)
+	GetOrgByIDCtx(ctx context.Context, orgID int) (*Org, error)
+	GetOrgByNameCtx(ctx context.Context, name string) (*Org, error)
+	CreateOrgCtx(ctx context.Context, org *Org) error
+	UpdateOrgCtx(ctx context.Context, org *Org) error
+	UpdateOrgAddressCtx(ctx context.Context, orgID int, address OrgAddress) error
+	DeleteOrgCtx(ctx context.Context, orgID int) error
+	GetOrgUsersCtx(ctx context.Context, orgID int) ([]OrgUser, error)
+	AddOrgUserCtx(ctx context.Context, orgID int, loginOrEmail, role string) error
+	UpdateOrgUserRoleCtx(ctx context.Context, orgID, userID int, role string) error
+	RemoveOrgUserCtx(ctx context.Context, orgID, userID int) error
+	GetOrgPreferencesCtx(ctx context.Context) (*Preferences, error)
+	UpdateOrgPreferencesCtx(ctx context.Context, prefs *Preferences) error
+	SearchUsersCtx(ctx context.Context, query string, perPage, page int) (*SearchUsersResponse, error)
+	GetUserByIDCtx(ctx context.Context, userID int) (*User, error)
+	GetUserByLoginOrEmailCtx(ctx context.Context, loginOrEmail string) (*User, error)
+	UpdateUserCtx(ctx context.Context, user *User) error
+	GetUserOrgsCtx(ctx context.Context, userID int) ([]UserOrg, error)
+	GetUserTeamsCtx(ctx context.Context, userID int) ([]Team, error)
+	CreateUserCtx(ctx context.Context, user *User, password string) error
+	SetUserPasswordCtx(ctx context.Context, userID int, password string) error
+	SetUserGrafanaAdminCtx(ctx context.Context, userID int, isGrafanaAdmin bool) error
+	DisableUserCtx(ctx context.Context, userID int) error
+	EnableUserCtx(ctx context.Context, userID int) error
+	LogoutUserCtx(ctx context.Context, userID int) error
+	DeleteUserCtx(ctx context.Context, userID int) error
+	SearchTeamsCtx(ctx context.Context, query string, perPage, page int) (*SearchTeamsResponse, error)
+	GetTeamByIDCtx(ctx context.Context, teamID int) (*Team, error)
+	CreateTeamCtx(ctx context.Context, team *Team) error
+	UpdateTeamCtx(ctx context.Context, team *Team) error
+	DeleteTeamCtx(ctx context.Context, teamID int) error
+	GetTeamMembersCtx(ctx context.Context, teamID int) ([]TeamMember, error)
+	AddTeamMemberCtx(ctx context.Context, teamID, userID int) error
+	RemoveTeamMemberCtx(ctx context.Context, teamID, userID int) error
+	SetTeamMembersCtx(ctx context.Context, teamID int, userIDs []int) error
+	GetTeamPreferencesCtx(ctx context.Context, teamID int) (*Preferences, error)
+	UpdateTeamPreferencesCtx(ctx context.Context, teamID int, prefs *Preferences) error
+	GetFolderPermissionsCtx(ctx context.Context, uid string) ([]PermissionEntry, error)
+	UpdateFolderPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) error
+	EnsureFolderPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) (bool, error)
+	GetDashboardPermissionsCtx(ctx context.Context, uid string) ([]PermissionEntry, error)
+	GetDashboardPermissionsByIDCtx(ctx context.Context, dashboardID int) ([]PermissionEntry, error)
+	UpdateDashboardPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) error
+	UpdateDashboardPermissionsByIDCtx(ctx context.Context, dashboardID int, items []PermissionItem) error
+	EnsureDashboardPermissionsCtx(ctx context.Context, uid string, items []PermissionItem) (bool, error)
+	GetFolderByUIDCtx(ctx context.Context, uid string) (*Folder, error)
+	GetFolderByIDCtx(ctx context.Context, folderId int) (*Folder, error)
+	CreateFolderCtx(ctx context.Context, folder *Folder) error
+	UpdateFolderCtx(ctx context.Context, folder *Folder, overwrite bool) error
+	DeleteFolderCtx(ctx context.Context, uid string, forceDeleteRules bool) error
+	EnsureFolderCtx(ctx context.Context, uid, title string) (*Folder, bool, error)
+	ListDashboardVersionsCtx(ctx context.Context, uid string, limit, start int) ([]DashboardVersion, error)
+	GetDashboardVersionCtx(ctx context.Context, uid string, version int) (*DashboardVersionDetail, error)
+	RestoreDashboardVersionCtx(ctx context.Context, uid string, version int) (*CreateDashboardResponse, error)
+	SearchCtx(ctx context.Context, query SearchQuery) ([]SearchHit, error)
+	SearchIterCtx(ctx context.Context, query SearchQuery) *SearchIterator
+	GetDashboardForHitCtx(ctx context.Context, hit SearchHit) (*Board, error)
 }
+
+// GrafanaClienter_7_0 在GrafanaClienter的基础上增加了Grafana 7.x之后才有的API，
+// 通过GetClientByVersion获取到"7.x"及更高版本的Client后可以断言为此接口。
+type GrafanaClienter_7_0 interface {
+	GrafanaClienter
+	NewDashboardInFolder(board *Board, folderUid string, overwrite bool) (*Board, error)
+	//UNIFIED ALERTING (Grafana 9.x+)
+	GetAlertRules() ([]AlertRule, error)
+	GetAlertRule(uid string) (*AlertRule, error)
+	CreateAlertRule(rule *AlertRule) error
+	UpdateAlertRule(rule *AlertRule) error
+	DeleteAlertRule(uid string) error
+	GetContactPoints() ([]ContactPoint, error)
+	CreateContactPoint(cp *ContactPoint) error
+
+	//NESTED FOLDERS (Grafana 10+)
+	GetFolderChildren(parentUID string) ([]Folder, error)
+	MoveFolder(uid, newParentUID string) (*Folder, error)
+	GetFolderByPath(path string) (*Folder, error)
+	EnsureFolderPath(path string) (*Folder, error)
+
+	//CONTEXT
+	NewDashboardInFolderCtx(ctx context.Context, board *Board, folderUid string, overwrite bool) (*Board, error)
+	GetAlertRulesCtx(ctx context.Context) ([]AlertRule, error)
+	GetAlertRuleCtx(ctx context.Context, uid string) (*AlertRule, error)
+	CreateAlertRuleCtx(ctx context.Context, rule *AlertRule) error
+	UpdateAlertRuleCtx(ctx context.Context, rule *AlertRule) error
+	DeleteAlertRuleCtx(ctx context.Context, uid string) error
+	GetContactPointsCtx(ctx context.Context) ([]ContactPoint, error)
+	CreateContactPointCtx(ctx context.Context, cp *ContactPoint) error
+	GetFolderChildrenCtx(ctx context.Context, parentUID string) ([]Folder, error)
+	MoveFolderCtx(ctx context.Context, uid, newParentUID string) (*Folder, error)
+	GetFolderByPathCtx(ctx context.Context, path string) (*Folder, error)
+	EnsureFolderPathCtx(ctx context.Context, path string) (*Folder, error)
+}
+
+var _ GrafanaClienter_7_0 = (*GrafanaClient_7_0)(nil)
@@ -1,5 +1,140 @@
 package gografana
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Grafana在412 Precondition failed响应中通过status字段说明无法保存Dashboard的原因
+const (
+	StatusVersionMismatch = "version-mismatch"
+	StatusNameExists      = "name-exists"
+	StatusPluginDashboard = "plugin-dashboard"
+)
+
+// ErrFolderNotFound 表示按照路径查找Folder时，路径中的某一级Folder不存在
+var ErrFolderNotFound = errors.New("folder not found")
+
+// ErrNestedFoldersUnsupported 表示Grafana不支持nested folders(需要Grafana 10+)
+var ErrNestedFoldersUnsupported = errors.New("nested folders are not supported by this Grafana")
+
 type ErrNoSpecifiedVerClient struct {
 	error
+	//请求的Grafana版本号
+	Version string
+	//当前已注册的Client版本
+	SupportedVersions []string
+}
+
+func newErrNoSpecifiedVerClient(version string, registered []*clientRegistration) ErrNoSpecifiedVerClient {
+	supported := make([]string, 0, len(registered))
+	for _, r := range registered {
+		supported = append(supported, r.constraint)
+	}
+	return ErrNoSpecifiedVerClient{
+		error:             fmt.Errorf("No Grafana client found for version %q, supported versions: %s", version, strings.Join(supported, ", ")),
+		Version:           version,
+		SupportedVersions: supported,
+	}
+}
+
+// APIError 表示Grafana API返回了非200/OK的状态码。
+// Message和Status字段来自于Grafana响应体中的"message"和"status"字段(如果存在的话)。
+type APIError struct {
+	StatusCode int
+	Message    string
+	Status     string
+	//调用时传入的API标识，例如"NewDashboard(api/dashboards/db)"
+	Flag   string
+	Method string
+	Path   string
+	Body   []byte
+}
+
+func newAPIError(req *http.Request, flag string, statusCode int, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Flag: flag, Body: body}
+	if req != nil {
+		e.Method = req.Method
+		if req.URL != nil {
+			e.Path = req.URL.Path
+		}
+	}
+	var rsp struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	}
+	if json.Unmarshal(body, &rsp) == nil {
+		e.Message = rsp.Message
+		e.Status = rsp.Status
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Remote API returned Non 200/OK status code in the %s response(%d), request: %s %s, body: %s", e.Flag, e.StatusCode, e.Method, e.Path, string(e.Body))
+}
+
+type NewDashboardError struct {
+	Err    error
+	Status string
+}
+
+func (e NewDashboardError) Error() string {
+	return fmt.Sprintf("Internal Error: %s, Status: %s", e.Err.Error(), e.Status)
+}
+
+func (e NewDashboardError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound 判断err是否为Grafana返回的404 Not Found(或者ErrFolderNotFound)
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound) || errors.Is(err, ErrFolderNotFound)
+}
+
+// IsUnauthorized 判断err是否为Grafana返回的401 Unauthorized
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden 判断err是否为Grafana返回的403 Access denied
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsPreconditionFailed 判断err是否为Grafana返回的412 Precondition failed
+func IsPreconditionFailed(err error) bool {
+	return hasStatusCode(err, http.StatusPreconditionFailed)
+}
+
+// IsVersionMismatch 判断Dashboard是否因为已经被其他人修改而保存失败
+func IsVersionMismatch(err error) bool {
+	return hasStatus(err, StatusVersionMismatch)
+}
+
+// IsNameExists 判断Dashboard是否因为同一个Folder下已存在同名(或者相同UID)的Dashboard而保存失败
+func IsNameExists(err error) bool {
+	return hasStatus(err, StatusNameExists)
+}
+
+// IsPluginDashboard 判断Dashboard是否因为属于某个插件而保存失败
+func IsPluginDashboard(err error) bool {
+	return hasStatus(err, StatusPluginDashboard)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+func hasStatus(err error, status string) bool {
+	var dashErr *NewDashboardError
+	if errors.As(err, &dashErr) && dashErr.Status == status {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == status
 }
@@ -0,0 +1,185 @@
+package gografana
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestEvent 描述了一次Grafana API调用(包含所有重试)，会被传递给Hooks中的各个回调
+type RequestEvent struct {
+	//调用时使用的API标识，例如"NewDashboard(api/dashboards/db)"
+	Flag string
+	//Flag中的API名称以及路径模板，例如"NewDashboard"和"/api/dashboards/db"
+	Operation    string
+	PathTemplate string
+	//即将发出的请求，BeforeRequest中可以修改它的Header
+	Request *http.Request
+	//仅在使用WithBodyCapture时设置
+	RequestBody  []byte
+	ResponseBody []byte
+	//没有收到响应时为-1
+	StatusCode int
+	Attempts   int
+	Start      time.Time
+	Latency    time.Duration
+	//网络错误，或者非2xx响应对应的*APIError
+	Err error
+}
+
+// Hooks 是Client的中间件，用于在不包装Transport的情况下观察每一次API调用。
+// 多个Hooks按照注册顺序调用BeforeRequest，按照相反的顺序调用AfterResponse和OnError。
+type Hooks struct {
+	//在请求发出前调用，返回的ctx会被用于这次调用(例如携带tracing的span)
+	BeforeRequest func(ctx context.Context, e *RequestEvent) context.Context
+	//收到响应后调用(无论状态码是多少)
+	AfterResponse func(ctx context.Context, e *RequestEvent)
+	//发生网络错误或者收到非2xx响应时调用
+	OnError func(ctx context.Context, e *RequestEvent)
+}
+
+// WithHooks 为Client添加一组Hooks，可以多次调用
+func WithHooks(hooks Hooks) Option {
+	return func(o *clientOptions) error {
+		o.hooks = append(o.hooks, hooks)
+		return nil
+	}
+}
+
+// WithBodyCapture 让RequestEvent携带请求和响应的body，redact用于在交给Hooks之前去除其中的敏感信息，
+// 可以使用RedactJSONFields，传入nil表示不做处理。
+func WithBodyCapture(redact func(body []byte) []byte) Option {
+	return func(o *clientOptions) error {
+		o.captureBodies = true
+		o.redact = redact
+		return nil
+	}
+}
+
+// RedactJSONFields 返回一个redact函数，它会将JSON中(任意层级)名称为fields之一的字段的值替换为"[REDACTED]"，
+// 对于不是JSON的body则整体替换。
+func RedactJSONFields(fields ...string) func(body []byte) []byte {
+	redacted := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		redacted[strings.ToLower(f)] = true
+	}
+	return func(body []byte) []byte {
+		if len(body) == 0 {
+			return body
+		}
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return []byte("[REDACTED]")
+		}
+		b, err := json.Marshal(redactValue(v, redacted))
+		if err != nil {
+			return []byte("[REDACTED]")
+		}
+		return b
+	}
+}
+
+// DefaultRedactedFields 是Grafana API中常见的敏感字段
+var DefaultRedactedFields = []string{"password", "oldPassword", "newPassword", "basicAuthPassword", "secureJsonData", "key", "token", "apiKey"}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if fields[strings.ToLower(k)] {
+				t[k] = "[REDACTED]"
+				continue
+			}
+			t[k] = redactValue(child, fields)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactValue(child, fields)
+		}
+	}
+	return v
+}
+
+// PathParams 根据PathTemplate从请求路径中解析出"[UID]"这类参数，例如{"UID": "abc"}
+func (e *RequestEvent) PathParams() map[string]string {
+	params := map[string]string{}
+	if e.PathTemplate == "" || e.Request == nil || e.Request.URL == nil {
+		return params
+	}
+	tpl := strings.Split(strings.Trim(e.PathTemplate, "/"), "/")
+	actual := strings.Split(strings.Trim(e.Request.URL.Path, "/"), "/")
+	//Grafana可能部署在子路径下(例如http://host/grafana)，因此从末尾对齐
+	if len(actual) < len(tpl) {
+		return params
+	}
+	actual = actual[len(actual)-len(tpl):]
+	for i, segment := range tpl {
+		if strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]") {
+			params[segment[1:len(segment)-1]] = actual[i]
+		}
+	}
+	return params
+}
+
+// parseFlag 将"NewDashboard(api/dashboards/db)"拆分为API名称和以"/"开头的路径模板
+func parseFlag(flag string) (string, string) {
+	i := strings.Index(flag, "(")
+	if i < 0 || !strings.HasSuffix(flag, ")") {
+		return flag, ""
+	}
+	path := flag[i+1 : len(flag)-1]
+	if j := strings.Index(path, "?"); j >= 0 {
+		path = path[:j]
+	}
+	return flag[:i], "/" + strings.TrimPrefix(path, "/")
+}
+
+func (bc *baseClient) newRequestEvent(req *http.Request, flag string) *RequestEvent {
+	event := &RequestEvent{Flag: flag, Request: req, StatusCode: -1, Start: time.Now()}
+	event.Operation, event.PathTemplate = parseFlag(flag)
+	if len(bc.options.hooks) > 0 && bc.options.captureBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, err := ioutil.ReadAll(body)
+			body.Close()
+			if err == nil {
+				event.RequestBody = bc.redactBody(data)
+			}
+		}
+	}
+	return event
+}
+
+func (bc *baseClient) redactBody(body []byte) []byte {
+	if bc.options.redact == nil {
+		return body
+	}
+	return bc.options.redact(body)
+}
+
+func (bc *baseClient) finishRequestEvent(ctx context.Context, event *RequestEvent, bodyData []byte, statusCode, attempts int, err error) {
+	if len(bc.options.hooks) == 0 {
+		return
+	}
+	event.Latency = time.Since(event.Start)
+	event.StatusCode = statusCode
+	event.Attempts = attempts
+	event.Err = err
+	if err == nil && !isSuccessStatusCode(statusCode) {
+		event.Err = newAPIError(event.Request, event.Flag, statusCode, bodyData)
+	}
+	if bc.options.captureBodies && bodyData != nil {
+		event.ResponseBody = bc.redactBody(bodyData)
+	}
+	for i := len(bc.options.hooks) - 1; i >= 0; i-- {
+		h := bc.options.hooks[i]
+		if statusCode > 0 && h.AfterResponse != nil {
+			h.AfterResponse(ctx, event)
+		}
+		if event.Err != nil && h.OnError != nil {
+			h.OnError(ctx, event)
+		}
+	}
+}
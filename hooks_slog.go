@@ -0,0 +1,44 @@
+//go:build go1.21
+
+package gografana
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogHooks 返回将每一次API调用记录到logger的Hooks，成功的调用使用Info级别，失败的调用使用Error级别。
+// 配合WithBodyCapture使用时会同时记录(经过redact处理的)请求和响应body。
+func NewSlogHooks(logger *slog.Logger) Hooks {
+	return Hooks{
+		AfterResponse: func(ctx context.Context, e *RequestEvent) {
+			if e.Err != nil {
+				return
+			}
+			logger.LogAttrs(ctx, slog.LevelInfo, "grafana api call", slogAttrs(e)...)
+		},
+		OnError: func(ctx context.Context, e *RequestEvent) {
+			attrs := append(slogAttrs(e), slog.String("error", e.Err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "grafana api call failed", attrs...)
+		},
+	}
+}
+
+func slogAttrs(e *RequestEvent) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("operation", e.Operation),
+		slog.String("flag", e.Flag),
+		slog.String("method", e.Request.Method),
+		slog.String("path", e.Request.URL.Path),
+		slog.Int("status", e.StatusCode),
+		slog.Int("attempts", e.Attempts),
+		slog.Duration("latency", e.Latency),
+	}
+	if e.RequestBody != nil {
+		attrs = append(attrs, slog.String("request_body", string(e.RequestBody)))
+	}
+	if e.ResponseBody != nil {
+		attrs = append(attrs, slog.String("response_body", string(e.ResponseBody)))
+	}
+	return attrs
+}
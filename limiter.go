@@ -0,0 +1,133 @@
+package gografana
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithRateLimit 使用令牌桶限制Client每秒发出的请求数，burst为允许瞬时发出的最大请求数(小于1时按1处理)。
+// 同一个Client的所有方法以及所有goroutine共享同一个令牌桶，重试的请求同样需要获取令牌。
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(o *clientOptions) error {
+		o.rateLimit = requestsPerSecond
+		o.rateBurst = burst
+		return nil
+	}
+}
+
+// WithMaxInFlight 限制Client同时进行中的请求数，超出的请求会排队等待(受ctx控制)
+func WithMaxInFlight(n int) Option {
+	return func(o *clientOptions) error {
+		o.maxInFlight = n
+		return nil
+	}
+}
+
+// LimiterStats 是限流相关的统计信息，可以用于观察请求因为限流而等待的时间
+type LimiterStats struct {
+	//因为令牌桶而需要等待的请求数，以及累计等待时间
+	RateLimitedRequests int64
+	RateLimitWait       time.Duration
+	//因为并发数限制而需要排队的请求数，以及累计排队时间
+	QueuedRequests int64
+	QueueWait      time.Duration
+	//当前进行中的请求数
+	InFlight int64
+}
+
+type limiterStats struct {
+	rateLimitedRequests int64
+	rateLimitWait       int64
+	queuedRequests      int64
+	queueWait           int64
+	inFlight            int64
+}
+
+// LimiterStats 返回Client创建以来的限流统计信息，通过WithOrg得到的Client与原Client共享同一份统计
+func (bc *baseClient) LimiterStats() LimiterStats {
+	return LimiterStats{
+		RateLimitedRequests: atomic.LoadInt64(&bc.stats.rateLimitedRequests),
+		RateLimitWait:       time.Duration(atomic.LoadInt64(&bc.stats.rateLimitWait)),
+		QueuedRequests:      atomic.LoadInt64(&bc.stats.queuedRequests),
+		QueueWait:           time.Duration(atomic.LoadInt64(&bc.stats.queueWait)),
+		InFlight:            atomic.LoadInt64(&bc.stats.inFlight),
+	}
+}
+
+// acquire 在发出请求之前依次等待令牌和并发名额，返回的release必须在请求结束后调用
+func (bc *baseClient) acquire(ctx context.Context) (release func(), err error) {
+	if bc.rateLimiter != nil {
+		waited, err := bc.rateLimiter.wait(ctx)
+		if waited > 0 {
+			atomic.AddInt64(&bc.stats.rateLimitedRequests, 1)
+			atomic.AddInt64(&bc.stats.rateLimitWait, int64(waited))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if bc.inFlight != nil {
+		select {
+		case bc.inFlight <- struct{}{}:
+		default:
+			start := time.Now()
+			select {
+			case bc.inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			atomic.AddInt64(&bc.stats.queuedRequests, 1)
+			atomic.AddInt64(&bc.stats.queueWait, int64(time.Since(start)))
+		}
+	}
+	atomic.AddInt64(&bc.stats.inFlight, 1)
+	return func() {
+		atomic.AddInt64(&bc.stats.inFlight, -1)
+		if bc.inFlight != nil {
+			<-bc.inFlight
+		}
+	}, nil
+}
+
+// tokenBucket 是一个简单的令牌桶，令牌不足时通过预约的方式排队，保证等待的请求按顺序获得令牌
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait 获取一个令牌，返回实际等待的时间
+func (tb *tokenBucket) wait(ctx context.Context) (time.Duration, error) {
+	tb.lock.Lock()
+	now := time.Now()
+	tb.tokens = math.Min(tb.burst, tb.tokens+now.Sub(tb.last).Seconds()*tb.rate)
+	tb.last = now
+	tb.tokens--
+	deficit := -tb.tokens
+	tb.lock.Unlock()
+	if deficit <= 0 {
+		return 0, nil
+	}
+	d := time.Duration(deficit / tb.rate * float64(time.Second))
+	start := time.Now()
+	if err := sleepContext(ctx, d); err != nil {
+		//请求被取消，归还预约的令牌
+		tb.lock.Lock()
+		tb.tokens++
+		tb.lock.Unlock()
+		return time.Since(start), err
+	}
+	return d, nil
+}
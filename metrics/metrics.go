@@ -0,0 +1,97 @@
+// Package metrics 为gografana的Client提供Prometheus指标，
+// 包括按API名称(例如"GetAllDashboards")和Grafana地址区分的请求数、错误数以及耗时分布。
+//
+//	m := metrics.New("")
+//	prometheus.MustRegister(m)
+//	client, err := gografana.NewClient(address, gografana.WithAuthenticator(auth), m.Option())
+package metrics
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/g0194776/gografana"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultNamespace = "gografana"
+
+// Metrics 实现了prometheus.Collector，同一个Metrics可以被多个Client共享
+type Metrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// New 创建一组指标，namespace为空时使用"gografana"
+func New(namespace string) *Metrics {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of Grafana API calls, by operation, Grafana host and status code.",
+		}, []string{"operation", "host", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_errors_total",
+			Help:      "Total number of failed Grafana API calls (network errors or non 2xx responses), by operation, Grafana host and status code.",
+		}, []string{"operation", "host", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of Grafana API calls including retries, by operation and Grafana host.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "host"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.errors.Describe(ch)
+	m.latency.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.errors.Collect(ch)
+	m.latency.Collect(ch)
+}
+
+// Hooks 返回用于记录指标的gografana.Hooks
+func (m *Metrics) Hooks() gografana.Hooks {
+	return gografana.Hooks{
+		AfterResponse: func(_ context.Context, e *gografana.RequestEvent) {
+			m.observe(e)
+		},
+		OnError: func(_ context.Context, e *gografana.RequestEvent) {
+			//没有收到响应时AfterResponse不会被调用
+			if e.StatusCode <= 0 {
+				m.observe(e)
+			}
+			m.errors.WithLabelValues(e.Operation, e.Request.URL.Host, code(e)).Inc()
+		},
+	}
+}
+
+// Option 返回可以直接传给gografana.NewClient或者gografana.GetClient的Option
+func (m *Metrics) Option() gografana.Option {
+	return gografana.WithHooks(m.Hooks())
+}
+
+func (m *Metrics) observe(e *gografana.RequestEvent) {
+	host := e.Request.URL.Host
+	m.requests.WithLabelValues(e.Operation, host, code(e)).Inc()
+	m.latency.WithLabelValues(e.Operation, host).Observe(e.Latency.Seconds())
+}
+
+func code(e *gografana.RequestEvent) string {
+	if e.StatusCode <= 0 {
+		return "error"
+	}
+	return strconv.Itoa(e.StatusCode)
+}
@@ -0,0 +1,236 @@
+package gografana
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option 用于在创建Client时定制其行为，例如授权方式、TLS配置、超时时间等
+type Option func(*clientOptions) error
+
+type clientOptions struct {
+	authenticator Authenticator
+	httpClient    *http.Client
+	transport     http.RoundTripper
+	tlsConfig     *tls.Config
+	proxy         *url.URL
+	proxyFromEnv  bool
+	timeout       time.Duration
+	userAgent     string
+	headers       http.Header
+	retryPolicy   RetryPolicy
+	rateLimit     float64
+	rateBurst     int
+	maxInFlight   int
+	hooks         []Hooks
+	captureBodies bool
+	redact        func(body []byte) []byte
+	serverVersion *semVersion
+}
+
+func newClientOptions(opts []Option) (*clientOptions, error) {
+	o := &clientOptions{headers: http.Header{}}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// newHTTPClient 根据选项创建http.Client，WithHTTPClient指定的Client优先于其它所有传输层相关的选项
+func (o *clientOptions) newHTTPClient() *http.Client {
+	if o.httpClient != nil {
+		return o.httpClient
+	}
+	rt := o.transport
+	if rt == nil {
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		if o.tlsConfig != nil {
+			tr.TLSClientConfig = o.tlsConfig
+		}
+		//默认直连Grafana，不读取HTTP_PROXY等环境变量，除非显式设置了WithProxyFromEnvironment
+		tr.Proxy = nil
+		if o.proxy != nil {
+			tr.Proxy = http.ProxyURL(o.proxy)
+		} else if o.proxyFromEnv {
+			tr.Proxy = http.ProxyFromEnvironment
+		}
+		rt = tr
+	}
+	return &http.Client{Transport: rt, Timeout: o.timeout}
+}
+
+func (o *clientOptions) tls() *tls.Config {
+	if o.tlsConfig == nil {
+		o.tlsConfig = &tls.Config{}
+	}
+	return o.tlsConfig
+}
+
+// WithAuthenticator 设置访问Grafana时使用的授权方式(Basic Auth/API Key)
+func WithAuthenticator(auth Authenticator) Option {
+	return func(o *clientOptions) error {
+		o.authenticator = auth
+		return nil
+	}
+}
+
+// WithHTTPClient 使用调用方提供的http.Client，此时WithRoundTripper、WithTLSConfig、WithProxy、WithTimeout等选项不再生效
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) error {
+		o.httpClient = client
+		return nil
+	}
+}
+
+// WithRoundTripper 使用调用方提供的RoundTripper，此时TLS以及代理相关的选项不再生效
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(o *clientOptions) error {
+		o.transport = rt
+		return nil
+	}
+}
+
+// WithTLSConfig 使用调用方提供的TLS配置，之后的TLS相关选项会在其副本上继续修改
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *clientOptions) error {
+		if cfg == nil {
+			o.tlsConfig = nil
+			return nil
+		}
+		o.tlsConfig = cfg.Clone()
+		return nil
+	}
+}
+
+// WithCABundle 使用PEM格式的CA证书校验Grafana服务端证书，而不是系统默认的根证书
+func WithCABundle(pemCerts []byte) Option {
+	return func(o *clientOptions) error {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemCerts) {
+			return fmt.Errorf("WithCABundle: no valid PEM certificates found")
+		}
+		o.tls().RootCAs = pool
+		return nil
+	}
+}
+
+// WithCAFile 与WithCABundle相同，CA证书从指定的文件中读取
+func WithCAFile(path string) Option {
+	return func(o *clientOptions) error {
+		pemCerts, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("WithCAFile: read CA file failed, error: %s", err.Error())
+		}
+		return WithCABundle(pemCerts)(o)
+	}
+}
+
+// WithClientCertificate 设置用于双向TLS认证的客户端证书
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(o *clientOptions) error {
+		o.tls().Certificates = append(o.tls().Certificates, cert)
+		return nil
+	}
+}
+
+// WithClientCertificateFiles 与WithClientCertificate相同，证书和私钥从PEM文件中读取
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return func(o *clientOptions) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("WithClientCertificateFiles: load key pair failed, error: %s", err.Error())
+		}
+		return WithClientCertificate(cert)(o)
+	}
+}
+
+// WithServerName 设置TLS握手时使用的SNI，同时也是校验服务端证书时使用的主机名
+func WithServerName(serverName string) Option {
+	return func(o *clientOptions) error {
+		o.tls().ServerName = serverName
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify 跳过对Grafana服务端证书的校验，仅建议在测试环境中使用
+func WithInsecureSkipVerify() Option {
+	return func(o *clientOptions) error {
+		o.tls().InsecureSkipVerify = true
+		return nil
+	}
+}
+
+// WithTimeout 设置单次HTTP请求的超时时间(包含读取响应体)
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent 设置每个请求的User-Agent
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHeader 为每个请求添加一个默认的HTTP Header，可以多次调用
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) error {
+		o.headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithProxy 设置访问Grafana时使用的http proxy，传入空字符串表示直连Grafana
+func WithProxy(httpProxy string) Option {
+	return func(o *clientOptions) error {
+		if httpProxy == "" {
+			o.proxy = nil
+			return nil
+		}
+		proxyUrl, err := url.Parse(httpProxy)
+		if err != nil {
+			return err
+		}
+		o.proxy = proxyUrl
+		return nil
+	}
+}
+
+// WithProxyFromEnvironment 使用环境变量(HTTP_PROXY、HTTPS_PROXY以及NO_PROXY)中的代理设置访问Grafana，
+// 同时设置了WithProxy时以WithProxy为准
+func WithProxyFromEnvironment() Option {
+	return func(o *clientOptions) error {
+		o.proxyFromEnv = true
+		return nil
+	}
+}
+
+// WithServerVersion 告知Client远程Grafana的完整版本号(例如"9.5.2")，Client据此选择可用的API而不必再探测，
+// GetClient以及传入完整版本号的GetClientByVersion会自动设置该Option
+func WithServerVersion(version string) Option {
+	return func(o *clientOptions) error {
+		v, specified, err := parseVersionParts(version)
+		if err != nil {
+			return err
+		}
+		if specified < 3 {
+			return fmt.Errorf("server version %q must be a full version like \"9.5.2\"", version)
+		}
+		o.serverVersion = &v
+		return nil
+	}
+}
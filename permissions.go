@@ -0,0 +1,51 @@
+package gografana
+
+import "fmt"
+
+// key 标识一个授权对象(用户、Team或者角色)
+func (p PermissionItem) key() string {
+	switch {
+	case p.UserID != 0:
+		return fmt.Sprintf("user:%d", p.UserID)
+	case p.TeamID != 0:
+		return fmt.Sprintf("team:%d", p.TeamID)
+	default:
+		return "role:" + p.Role
+	}
+}
+
+// ownPermissionItems 返回entries中直接设置在该对象上的授权，继承自Folder的授权会被忽略
+func ownPermissionItems(entries []PermissionEntry) []PermissionItem {
+	items := make([]PermissionItem, 0, len(entries))
+	for _, e := range entries {
+		if e.Inherited {
+			continue
+		}
+		items = append(items, e.Item())
+	}
+	return items
+}
+
+// permissionsEqual 判断两组授权是否等价(忽略顺序)，同一个授权对象出现多次时以最高的权限为准
+func permissionsEqual(current, desired []PermissionItem) bool {
+	a, b := effectivePermissions(current), effectivePermissions(desired)
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func effectivePermissions(items []PermissionItem) map[string]int {
+	m := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.Permission > m[item.key()] {
+			m[item.key()] = item.Permission
+		}
+	}
+	return m
+}
@@ -0,0 +1,207 @@
+package gografana
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// 缓存每个类型中带有json tag的字段名(小写，与encoding/json大小写不敏感的匹配规则一致)以及字段的类型
+var knownFieldsCache sync.Map
+
+func knownJSONFields(t reflect.Type) map[string]reflect.Type {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]reflect.Type)
+	}
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, kt := range knownJSONFields(ft) {
+					fields[k] = kt
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	knownFieldsCache.Store(t, fields)
+	return fields
+}
+
+// decodeObject 将data解析到v(指向一个没有方法的别名类型)，返回data中没有被v建模的字段(extra)以及data的全部字段(raw)
+func decodeObject(data []byte, v interface{}) (extra, raw map[string]json.RawMessage, err error) {
+	if err = json.Unmarshal(data, v); err != nil {
+		return nil, nil, err
+	}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+	known := knownJSONFields(reflect.TypeOf(v).Elem())
+	for k, val := range raw {
+		if _, ok := known[strings.ToLower(k)]; ok {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[k] = val
+	}
+	return extra, raw, nil
+}
+
+// encodeObject 序列化v并写回extra中的字段。raw不为空(即对象是从Grafana返回的JSON解析而来)时，
+// 原始JSON中没有并且当前为零值的字段不会被输出(无法区分调用方是否主动设置了零值，需要时可以通过extra强制输出)；
+// mergeType不为nil时，还会按照mergeType把raw中嵌套结构体里没有被建模的字段合并回去，见mergeJSON。
+func encodeObject(v interface{}, extra, raw map[string]json.RawMessage, mergeType reflect.Type) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || (len(extra) == 0 && raw == nil) {
+		return data, err
+	}
+	var out map[string]json.RawMessage
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	var fields map[string]reflect.Type
+	if mergeType != nil {
+		fields = knownJSONFields(mergeType)
+	}
+	for k, val := range out {
+		old, ok := raw[k]
+		if raw != nil && !ok && isZeroJSON(val) {
+			delete(out, k)
+			continue
+		}
+		//字段的Go类型无法表示null(例如"datasource": null)，没有被修改时保留原始的null
+		if ok && isNullJSON(old) && isZeroJSON(val) {
+			out[k] = old
+			continue
+		}
+		if ft, known := fields[strings.ToLower(k)]; ok && known {
+			out[k] = mergeJSON(val, old, ft)
+		}
+	}
+	for k, val := range extra {
+		if _, ok := out[k]; !ok {
+			out[k] = val
+		}
+	}
+	return json.Marshal(out)
+}
+
+// mergeJSON 以newer为准，把older中t(newer对应的Go类型)没有建模的字段补回去，t建模的字段不会被恢复，
+// 因此调用方删除或者修改的内容不会被覆盖。map以及interface{}类型的值完全以newer为准；
+// 结构体数组只有在长度不变时，对建模部分没有被修改的元素逐个合并，被替换或者修改过的元素以newer为准。
+func mergeJSON(newer, older json.RawMessage, t reflect.Type) json.RawMessage {
+	if isNullJSON(older) && isZeroJSON(newer) {
+		return older
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		var n, o map[string]json.RawMessage
+		if json.Unmarshal(newer, &n) != nil || json.Unmarshal(older, &o) != nil || n == nil || o == nil {
+			return newer
+		}
+		fields := knownJSONFields(t)
+		for k, nv := range n {
+			ov, ok := o[k]
+			if !ok {
+				if isZeroJSON(nv) {
+					delete(n, k)
+				}
+				continue
+			}
+			if ft, known := fields[strings.ToLower(k)]; known {
+				n[k] = mergeJSON(nv, ov, ft)
+			}
+		}
+		for k, ov := range o {
+			if _, ok := n[k]; ok {
+				continue
+			}
+			if _, known := fields[strings.ToLower(k)]; !known {
+				n[k] = ov
+			}
+		}
+		if merged, err := json.Marshal(n); err == nil {
+			return merged
+		}
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return newer
+		}
+		var na, oa []json.RawMessage
+		if json.Unmarshal(newer, &na) != nil || json.Unmarshal(older, &oa) != nil || na == nil || len(na) != len(oa) {
+			return newer
+		}
+		for i := range na {
+			if sameModeledJSON(na[i], oa[i], elem) {
+				na[i] = mergeJSON(na[i], oa[i], elem)
+			}
+		}
+		if merged, err := json.Marshal(na); err == nil {
+			return merged
+		}
+	}
+	return newer
+}
+
+// sameModeledJSON 判断newer与older按照t解析后建模的部分是否相同，即调用方没有替换或者修改这个元素
+func sameModeledJSON(newer, older json.RawMessage, t reflect.Type) bool {
+	decoded := reflect.New(t)
+	if json.Unmarshal(older, decoded.Interface()) != nil {
+		return false
+	}
+	remarshaled, err := json.Marshal(decoded.Interface())
+	if err != nil {
+		return false
+	}
+	var a, b interface{}
+	if json.Unmarshal(newer, &a) != nil || json.Unmarshal(remarshaled, &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isNullJSON(val json.RawMessage) bool {
+	return string(bytes.TrimSpace(val)) == "null"
+}
+
+// isZeroJSON 判断val是否为零值，所有字段都为零值的对象(例如没有设置的gridPos)也视为零值
+func isZeroJSON(val json.RawMessage) bool {
+	switch string(bytes.TrimSpace(val)) {
+	case "null", "false", "0", `""`, "[]", "{}":
+		return true
+	}
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(val, &obj) != nil || obj == nil {
+		return false
+	}
+	for _, v := range obj {
+		if !isZeroJSON(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package gografana
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy 描述了Client对临时性失败的重试策略。
+// 只有429、5xx(501除外)以及连接被重置/拒绝这类错误会被重试，默认只重试幂等的请求(GET/HEAD/PUT/DELETE等)，
+// 对于POST请求可以设置RetryNonIdempotent，或者仅对单次调用使用AllowRetry(ctx)。
+type RetryPolicy struct {
+	//包含第一次请求在内的最大尝试次数，小于等于1表示不重试
+	MaxAttempts int
+	//第一次重试前的等待时间，之后每次乘以Multiplier，但不超过MaxBackoff。
+	//Retry-After要求的等待时间超过MaxBackoff时不再重试，直接返回错误
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	//等待时间中随机化的比例(0~1)，用于避免大量Client同时重试
+	Jitter float64
+	//是否重试POST这类非幂等的请求
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy 返回一个适合大多数场景的重试策略: 最多尝试4次，等待时间从200ms开始指数增长
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetryPolicy 为Client设置重试策略，默认不重试
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retryPolicy = policy
+		return nil
+	}
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry 返回一个新的ctx，使用它发起的非幂等请求也会按照RetryPolicy进行重试，
+// 适用于可以安全重放的POST调用，例如overwrite=true时的NewDashboardCtx。
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+func (p RetryPolicy) allows(req *http.Request) bool {
+	if p.MaxAttempts <= 1 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	if p.RetryNonIdempotent {
+		return true
+	}
+	allowed, _ := req.Context().Value(allowRetryKey{}).(bool)
+	return allowed
+}
+
+func isRetryableStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || (statusCode >= 500 && statusCode != http.StatusNotImplemented)
+}
+
+func isRetryableError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// backoff 返回第attempt次尝试失败后需要等待的时间，Grafana(或者其前面的代理)返回的Retry-After优先，
+// Retry-After超过MaxBackoff时返回false表示不再重试
+func (p RetryPolicy) backoff(attempt int, header http.Header) (time.Duration, bool) {
+	if d, ok := parseRetryAfter(header); ok {
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			return 0, false
+		}
+		return d, true
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d = d*(1-jitter) + d*jitter*rand.Float64()
+	}
+	return time.Duration(d), true
+}
+
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleepContext 等待d时间，如果ctx先结束则返回ctx的错误
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
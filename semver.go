@@ -0,0 +1,174 @@
+package gografana
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semVersion 是Grafana版本号的major.minor.patch部分，预发布和构建信息(例如"-beta1")会被忽略
+type semVersion struct {
+	major, minor, patch int
+}
+
+func (v semVersion) compare(o semVersion) int {
+	switch {
+	case v.major != o.major:
+		return compareInt(v.major, o.major)
+	case v.minor != o.minor:
+		return compareInt(v.minor, o.minor)
+	default:
+		return compareInt(v.patch, o.patch)
+	}
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// parseVersion 解析"8.5.3"、"v9.0.0-beta1"或者"5.x"这类版本字符串，缺失或者通配的部分按0处理
+func parseVersion(s string) (semVersion, error) {
+	v, _, err := parseVersionParts(s)
+	return v, err
+}
+
+// parseVersionParts 额外返回版本字符串中明确给出的(非通配的)部分的数量
+func parseVersionParts(s string) (semVersion, int, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return semVersion{}, 0, fmt.Errorf("invalid version %q", raw)
+	}
+	var nums [3]int
+	specified := 0
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semVersion{}, 0, fmt.Errorf("invalid version %q", raw)
+		}
+		nums[i] = n
+		specified++
+	}
+	return semVersion{nums[0], nums[1], nums[2]}, specified, nil
+}
+
+type comparator struct {
+	op string
+	v  semVersion
+}
+
+func (c comparator) check(v semVersion) bool {
+	r := v.compare(c.v)
+	switch c.op {
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	case "!=":
+		return r != 0
+	default:
+		return r == 0
+	}
+}
+
+// versionConstraint 是以"||"分隔的多组条件，每组内的条件需要同时满足。支持的写法例如:
+//
+//	"5.x"、"8.2.x"、"*"、"8.2.1"、">=8.0 <9.0"、">=8.0, <9.0"、"~8.2"、"^8.2"、"5.x || 6.x"
+type versionConstraint [][]comparator
+
+func parseConstraint(s string) (versionConstraint, error) {
+	var c versionConstraint
+	for _, group := range strings.Split(s, "||") {
+		var comparators []comparator
+		for _, term := range strings.FieldsFunc(group, func(r rune) bool { return r == ' ' || r == ',' }) {
+			parsed, err := parseComparator(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q: %s", s, err.Error())
+			}
+			comparators = append(comparators, parsed...)
+		}
+		if len(comparators) == 0 {
+			return nil, fmt.Errorf("invalid version constraint %q: empty condition", s)
+		}
+		c = append(c, comparators)
+	}
+	return c, nil
+}
+
+func parseComparator(term string) ([]comparator, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", "!=", "==", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			break
+		}
+	}
+	v, specified, err := parseVersionParts(term[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "", "=", "==":
+		if specified == 3 {
+			return []comparator{{op: "=", v: v}}, nil
+		}
+		//"8"、"8.x"、"8.2.x"表示对应范围内的任意版本
+		return []comparator{{op: ">=", v: v}, {op: "<", v: nextVersion(v, specified)}}, nil
+	case "~":
+		if specified < 2 {
+			return []comparator{{op: ">=", v: v}, {op: "<", v: nextVersion(v, 1)}}, nil
+		}
+		return []comparator{{op: ">=", v: v}, {op: "<", v: nextVersion(v, 2)}}, nil
+	case "^":
+		return []comparator{{op: ">=", v: v}, {op: "<", v: nextVersion(v, 1)}}, nil
+	default:
+		return []comparator{{op: op, v: v}}, nil
+	}
+}
+
+// nextVersion 返回对v的前specified个部分中最后一个加1后的版本，例如(8.2.0, 2) -> 8.3.0
+func nextVersion(v semVersion, specified int) semVersion {
+	switch specified {
+	case 0:
+		return semVersion{major: 1 << 30}
+	case 1:
+		return semVersion{major: v.major + 1}
+	case 2:
+		return semVersion{major: v.major, minor: v.minor + 1}
+	default:
+		return semVersion{major: v.major, minor: v.minor, patch: v.patch + 1}
+	}
+}
+
+func (c versionConstraint) check(v semVersion) bool {
+	for _, group := range c {
+		matched := true
+		for _, cmp := range group {
+			if !cmp.check(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
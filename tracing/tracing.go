@@ -0,0 +1,89 @@
+// Package tracing 为gografana的Client提供OpenTelemetry支持:
+// 每一次API调用(包含所有重试)对应一个client span，并通过全局的TextMapPropagator将trace context传递给Grafana。
+//
+//	client, err := gografana.NewClient(address, gografana.WithAuthenticator(auth), tracing.New(nil).Option())
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/g0194776/gografana"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/g0194776/gografana/tracing"
+
+// Tracing 为Client创建span，同一个Tracing可以被多个Client共享
+type Tracing struct {
+	tracer trace.Tracer
+}
+
+// New 使用指定的TracerProvider创建Tracing，tp为nil时使用otel.GetTracerProvider()
+func New(tp trace.TracerProvider) *Tracing {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Tracing{tracer: tp.Tracer(instrumentationName)}
+}
+
+// Hooks 返回用于创建span的gografana.Hooks
+func (t *Tracing) Hooks() gografana.Hooks {
+	return gografana.Hooks{
+		BeforeRequest: func(ctx context.Context, e *gografana.RequestEvent) context.Context {
+			ctx, _ = t.tracer.Start(ctx, "grafana."+e.Operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attributes(e)...))
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(e.Request.Header))
+			return ctx
+		},
+		AfterResponse: func(ctx context.Context, e *gografana.RequestEvent) {
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(attribute.Int("http.response.status_code", e.StatusCode))
+			//非2xx的响应会接着调用OnError，由OnError结束span
+			if e.Err == nil {
+				span.SetAttributes(attribute.Int("grafana.attempts", e.Attempts))
+				span.End()
+			}
+		},
+		OnError: func(ctx context.Context, e *gografana.RequestEvent) {
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(attribute.Int("grafana.attempts", e.Attempts))
+			span.RecordError(e.Err)
+			span.SetStatus(codes.Error, e.Err.Error())
+			span.End()
+		},
+	}
+}
+
+// Option 返回可以直接传给gografana.NewClient或者gografana.GetClient的Option
+func (t *Tracing) Option() gografana.Option {
+	return gografana.WithHooks(t.Hooks())
+}
+
+func attributes(e *gografana.RequestEvent) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("grafana.operation", e.Operation),
+		attribute.String("http.request.method", e.Request.Method),
+		attribute.String("url.template", e.PathTemplate),
+		attribute.String("server.address", e.Request.URL.Hostname()),
+	}
+	params := e.PathParams()
+	if uid, ok := params["UID"]; ok {
+		if strings.Contains(e.PathTemplate, "/dashboards/") {
+			attrs = append(attrs, attribute.String("grafana.dashboard.uid", uid))
+		} else if strings.Contains(e.PathTemplate, "/folders/") {
+			attrs = append(attrs, attribute.String("grafana.folder.uid", uid))
+		}
+	}
+	if folderId, ok := params["FOLDER-ID"]; ok {
+		attrs = append(attrs, attribute.String("grafana.folder.id", folderId))
+	} else if folderIds := e.Request.URL.Query().Get("folderIds"); folderIds != "" {
+		attrs = append(attrs, attribute.String("grafana.folder.id", folderIds))
+	}
+	return attrs
+}
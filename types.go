@@ -2,6 +2,7 @@ package gografana
 
 import (
 	"encoding/json"
+	"reflect"
 	"time"
 )
 
@@ -23,12 +24,35 @@ type Board struct {
 	FolderUrl    string   `json:"folderUrl"`
 	Url          string   `json:"url,omitempty"` //TODO: ??
 	Rows         []*Row   `json:"rows"`
+	//Grafana 5.0之后Dashboard使用顶层的panels来描述面板，Row本身也是一个type为"row"的Panel
+	Panels        []*Panel_7_0 `json:"panels,omitempty"`
+	SchemaVersion int          `json:"schemaVersion,omitempty"`
+	//Dashboard JSON中没有被上面字段建模的部分(例如templating、annotations、links、time等)，保存时会原样写回。
+	//注意: 对于从Grafana获取的Dashboard，原始JSON中没有的字段只要保存时仍是零值就不会被输出，即使是调用方主动设置的零值
+	//(例如原始JSON中没有editable时设置Editable = false)。需要输出这样的零值时请写入Extra，例如Extra["editable"] = json.RawMessage("false")。
+	//Row、Panel_5_0以及Panel_7_0的Extra与此相同。
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   map[string]json.RawMessage
+}
+
+func (b Board) MarshalJSON() ([]byte, error) {
+	type Alias Board
+	return encodeObject(Alias(b), b.Extra, b.raw, nil)
+}
+
+func (b *Board) UnmarshalJSON(data []byte) error {
+	type Alias Board
+	var err error
+	b.Extra, b.raw, err = decodeObject(data, (*Alias)(b))
+	return err
 }
 
 type CreateDashboardRequest struct {
 	Board Board `json:"dashboard"`
 	//The id of the folder to save the dashboard in.
 	FolderId uint `json:"folderId"`
+	//The UID of the folder to save the dashboard in. Overrides the folderId (Grafana 7.x+).
+	FolderUid string `json:"folderUid,omitempty"`
 	//Set to true if you want to overwrite existing dashboard with newer version, same dashboard title in folder or same dashboard uid.
 	Overwrite bool `json:"overwrite,omitempty"`
 	//Set a commit message for the version history.
@@ -38,6 +62,8 @@ type CreateDashboardRequest struct {
 type CreateFolderRequest struct {
 	UID   string `json:"uid"`
 	Title string `json:"title"`
+	//The UID of the parent folder, only supported by Grafana 10+ with nested folders enabled.
+	ParentUID string `json:"parentUid,omitempty"`
 }
 
 type CreateFolderResponse struct {
@@ -61,6 +87,21 @@ type CreateAPIKeyResponse struct {
 	Key  string `json:"key"`
 }
 
+// SaveDashboardOptions controls how SaveDashboard stores a dashboard.
+type SaveDashboardOptions struct {
+	//Commit message shown in the dashboard's version history.
+	Message string
+	//Overwrite an existing dashboard with the same uid or the same title in the folder.
+	Overwrite bool
+	//The id of the folder to save the dashboard in.
+	FolderID uint
+	//The UID of the folder to save the dashboard in. Overrides FolderID (Grafana 7.x+).
+	FolderUID string
+	//If not zero, save only when the dashboard's current version in Grafana equals this value,
+	//a concurrent change yields an error satisfying IsVersionMismatch. Cannot be combined with Overwrite.
+	ExpectedVersion uint
+}
+
 /*
 Status Codes:
 ---------------
@@ -187,6 +228,10 @@ type Panel_5_0 struct {
 		Min     interface{} `json:"min"`
 		Show    bool        `json:"show"`
 	} `json:"yaxes,omitempty"`
+	//Panel JSON中没有被上面字段建模的部分，保存时会原样写回。上面的嵌套结构(例如legend、targets)中没有被建模的字段同样会被保留，
+	//但数组中被替换或者修改过的元素(例如修改了expr的target)以当前的值为准，不再保留原来未建模的字段
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   map[string]json.RawMessage
 }
 
 func (p *Panel_5_0) MarshalJSON() ([]byte, error) {
@@ -195,13 +240,20 @@ func (p *Panel_5_0) MarshalJSON() ([]byte, error) {
 	if p.AliasColors != nil {
 		aliasColors = p.AliasColors
 	}
-	return json.Marshal(&struct {
+	return encodeObject(&struct {
 		AliasColors map[string]string `json:"aliasColors"`
 		*Alias
 	}{
 		AliasColors: aliasColors,
 		Alias:       (*Alias)(p),
-	})
+	}, p.Extra, p.raw, reflect.TypeOf(Panel_5_0{}))
+}
+
+func (p *Panel_5_0) UnmarshalJSON(data []byte) error {
+	type Alias Panel_5_0
+	var err error
+	p.Extra, p.raw, err = decodeObject(data, (*Alias)(p))
+	return err
 }
 
 type Row struct {
@@ -211,6 +263,64 @@ type Row struct {
 	Editable  bool        `json:"editable"`
 	Height    string      `json:"height"`
 	Panels    []Panel_5_0 `json:"panels"`
+	//Row JSON中没有被上面字段建模的部分，保存时会原样写回
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   map[string]json.RawMessage
+}
+
+func (r Row) MarshalJSON() ([]byte, error) {
+	type Alias Row
+	return encodeObject(Alias(r), r.Extra, r.raw, nil)
+}
+
+func (r *Row) UnmarshalJSON(data []byte) error {
+	type Alias Row
+	var err error
+	r.Extra, r.raw, err = decodeObject(data, (*Alias)(r))
+	return err
+}
+
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Panel_7_0 represents a panel of the modern (Grafana 7.x+) dashboard model.
+// Panel specific settings live in Options and FieldConfig, whose shape depends on the panel type.
+type Panel_7_0 struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	//Grafana 8.3之前为数据源名称，之后为{"type": "...", "uid": "..."}
+	Datasource    interface{}              `json:"datasource,omitempty"`
+	GridPos       GridPos                  `json:"gridPos"`
+	Targets       []map[string]interface{} `json:"targets,omitempty"`
+	FieldConfig   map[string]interface{}   `json:"fieldConfig,omitempty"`
+	Options       map[string]interface{}   `json:"options,omitempty"`
+	Transparent   bool                     `json:"transparent,omitempty"`
+	Interval      string                   `json:"interval,omitempty"`
+	MaxDataPoints int                      `json:"maxDataPoints,omitempty"`
+	//only used by panels of type "row"
+	Collapsed bool         `json:"collapsed,omitempty"`
+	Panels    []*Panel_7_0 `json:"panels,omitempty"`
+	//Panel JSON中没有被上面字段建模的部分(例如links、thresholds、timeFrom等)，保存时会原样写回
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   map[string]json.RawMessage
+}
+
+func (p Panel_7_0) MarshalJSON() ([]byte, error) {
+	type Alias Panel_7_0
+	return encodeObject(Alias(p), p.Extra, p.raw, nil)
+}
+
+func (p *Panel_7_0) UnmarshalJSON(data []byte) error {
+	type Alias Panel_7_0
+	var err error
+	p.Extra, p.raw, err = decodeObject(data, (*Alias)(p))
+	return err
 }
 
 type GetDashboardByUIdResponse struct {
@@ -295,6 +405,9 @@ type Folder struct {
 	UpdatedBy string    `json:"updatedBy"`
 	Updated   time.Time `json:"updated"`
 	Version   int       `json:"version"`
+	//Nested folders (Grafana 10+): the parent folder and all ancestors from the root down to the parent
+	ParentUID string   `json:"parentUid,omitempty"`
+	Parents   []Folder `json:"parents,omitempty"`
 }
 
 type NotificationChannel struct {
@@ -314,3 +427,279 @@ type NotificationChannel struct {
 		UploadImage bool   `json:"uploadImage"`
 	} `json:"settings"`
 }
+
+// AlertRule represents a Grafana managed alert rule of the unified alerting provisioning API (Grafana 9.x+).
+type AlertRule struct {
+	ID           int64             `json:"id,omitempty"`
+	UID          string            `json:"uid,omitempty"`
+	OrgID        int64             `json:"orgID"`
+	FolderUID    string            `json:"folderUID"`
+	RuleGroup    string            `json:"ruleGroup"`
+	Title        string            `json:"title"`
+	Condition    string            `json:"condition"`
+	Data         []AlertQuery      `json:"data"`
+	Updated      time.Time         `json:"updated"`
+	NoDataState  string            `json:"noDataState"`
+	ExecErrState string            `json:"execErrState"`
+	For          string            `json:"for"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	IsPaused     bool              `json:"isPaused"`
+	Provenance   string            `json:"provenance,omitempty"`
+}
+
+type AlertQuery struct {
+	RefID             string `json:"refId"`
+	QueryType         string `json:"queryType"`
+	RelativeTimeRange struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	} `json:"relativeTimeRange"`
+	DatasourceUID string                 `json:"datasourceUid"`
+	Model         map[string]interface{} `json:"model"`
+}
+
+// ContactPoint represents a receiver of the unified alerting provisioning API (Grafana 9.x+).
+type ContactPoint struct {
+	UID                   string                 `json:"uid,omitempty"`
+	Name                  string                 `json:"name"`
+	Type                  string                 `json:"type"`
+	Settings              map[string]interface{} `json:"settings"`
+	DisableResolveMessage bool                   `json:"disableResolveMessage"`
+	Provenance            string                 `json:"provenance,omitempty"`
+}
+
+// Organization roles
+const (
+	OrgRoleViewer = "Viewer"
+	OrgRoleEditor = "Editor"
+	OrgRoleAdmin  = "Admin"
+)
+
+type Org struct {
+	ID      int        `json:"id"`
+	Name    string     `json:"name"`
+	Address OrgAddress `json:"address"`
+}
+
+type OrgAddress struct {
+	Address1 string `json:"address1"`
+	Address2 string `json:"address2"`
+	City     string `json:"city"`
+	ZipCode  string `json:"zipCode"`
+	State    string `json:"state"`
+	Country  string `json:"country"`
+}
+
+type CreateOrgResponse struct {
+	OrgID   int    `json:"orgId"`
+	Message string `json:"message"`
+}
+
+type OrgUser struct {
+	OrgID         int       `json:"orgId"`
+	UserID        int       `json:"userId"`
+	Email         string    `json:"email"`
+	Name          string    `json:"name"`
+	Login         string    `json:"login"`
+	Role          string    `json:"role"`
+	AvatarURL     string    `json:"avatarUrl"`
+	LastSeenAt    time.Time `json:"lastSeenAt"`
+	LastSeenAtAge string    `json:"lastSeenAtAge"`
+}
+
+// Preferences represents the preferences of an organization, a team or a user.
+type Preferences struct {
+	Theme            string `json:"theme"`
+	HomeDashboardID  int    `json:"homeDashboardId"`
+	HomeDashboardUID string `json:"homeDashboardUID,omitempty"`
+	Timezone         string `json:"timezone"`
+	WeekStart        string `json:"weekStart,omitempty"`
+}
+
+type User struct {
+	ID             int       `json:"id"`
+	OrgID          int       `json:"orgId,omitempty"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Login          string    `json:"login"`
+	Theme          string    `json:"theme,omitempty"`
+	AvatarURL      string    `json:"avatarUrl,omitempty"`
+	IsGrafanaAdmin bool      `json:"isGrafanaAdmin"`
+	IsDisabled     bool      `json:"isDisabled"`
+	IsExternal     bool      `json:"isExternal"`
+	AuthLabels     []string  `json:"authLabels,omitempty"`
+	LastSeenAt     time.Time `json:"lastSeenAt"`
+	LastSeenAtAge  string    `json:"lastSeenAtAge,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type SearchUsersResponse struct {
+	TotalCount int    `json:"totalCount"`
+	Users      []User `json:"users"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"perPage"`
+}
+
+// UserOrg represents an organization the user belongs to and the user's role in it.
+type UserOrg struct {
+	OrgID int    `json:"orgId"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+}
+
+type CreateUserResponse struct {
+	ID      int    `json:"id"`
+	Message string `json:"message"`
+}
+
+type Team struct {
+	ID          int    `json:"id"`
+	OrgID       int    `json:"orgId"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	AvatarURL   string `json:"avatarUrl"`
+	MemberCount int    `json:"memberCount"`
+	Permission  int    `json:"permission"`
+}
+
+type SearchTeamsResponse struct {
+	TotalCount int    `json:"totalCount"`
+	Teams      []Team `json:"teams"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"perPage"`
+}
+
+type CreateTeamResponse struct {
+	TeamID  int    `json:"teamId"`
+	Message string `json:"message"`
+}
+
+type TeamMember struct {
+	OrgID      int      `json:"orgId"`
+	TeamID     int      `json:"teamId"`
+	UserID     int      `json:"userId"`
+	Email      string   `json:"email"`
+	Name       string   `json:"name"`
+	Login      string   `json:"login"`
+	AvatarURL  string   `json:"avatarUrl"`
+	Labels     []string `json:"labels"`
+	Permission int      `json:"permission"`
+}
+
+// Permission levels of folder and dashboard ACLs
+const (
+	PermissionView  = 1
+	PermissionEdit  = 2
+	PermissionAdmin = 4
+)
+
+// PermissionItem grants Permission to exactly one of a user, a team or an organization role.
+type PermissionItem struct {
+	UserID     int    `json:"userId,omitempty"`
+	TeamID     int    `json:"teamId,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Permission int    `json:"permission"`
+}
+
+func UserPermission(userID, permission int) PermissionItem {
+	return PermissionItem{UserID: userID, Permission: permission}
+}
+
+func TeamPermission(teamID, permission int) PermissionItem {
+	return PermissionItem{TeamID: teamID, Permission: permission}
+}
+
+func RolePermission(role string, permission int) PermissionItem {
+	return PermissionItem{Role: role, Permission: permission}
+}
+
+// PermissionEntry is an ACL entry of a folder or a dashboard as returned by Grafana.
+type PermissionEntry struct {
+	ID             int       `json:"id"`
+	FolderID       int       `json:"folderId"`
+	DashboardID    int       `json:"dashboardId"`
+	UserID         int       `json:"userId"`
+	UserLogin      string    `json:"userLogin"`
+	UserEmail      string    `json:"userEmail"`
+	TeamID         int       `json:"teamId"`
+	Team           string    `json:"team"`
+	Role           string    `json:"role"`
+	Permission     int       `json:"permission"`
+	PermissionName string    `json:"permissionName"`
+	UID            string    `json:"uid"`
+	Title          string    `json:"title"`
+	Slug           string    `json:"slug"`
+	IsFolder       bool      `json:"isFolder"`
+	URL            string    `json:"url"`
+	Inherited      bool      `json:"inherited"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
+
+// Item returns the grant described by the entry.
+func (e PermissionEntry) Item() PermissionItem {
+	return PermissionItem{UserID: e.UserID, TeamID: e.TeamID, Role: e.Role, Permission: e.Permission}
+}
+
+type UpdatePermissionsRequest struct {
+	Items []PermissionItem `json:"items"`
+}
+
+type UpdateFolderRequest struct {
+	UID   string `json:"uid,omitempty"`
+	Title string `json:"title"`
+	//The version of the folder the update is based on, used to detect concurrent modifications.
+	Version int `json:"version,omitempty"`
+	//Set to true to update the folder even if its version changed in the meantime.
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+type MoveFolderRequest struct {
+	//The UID of the new parent folder, empty to move the folder to the root
+	ParentUID string `json:"parentUid"`
+}
+
+// DashboardVersion is the metadata of one saved version of a dashboard,
+// as returned by the dashboard versions API.
+type DashboardVersion struct {
+	ID            int       `json:"id"`
+	DashboardID   int       `json:"dashboardId"`
+	DashboardUID  string    `json:"uid,omitempty"`
+	ParentVersion int       `json:"parentVersion"`
+	RestoredFrom  int       `json:"restoredFrom"`
+	Version       int       `json:"version"`
+	Created       time.Time `json:"created"`
+	CreatedBy     string    `json:"createdBy"`
+	Message       string    `json:"message"`
+}
+
+// DashboardVersionDetail is a dashboard version together with the dashboard model saved in it.
+type DashboardVersionDetail struct {
+	DashboardVersion
+	Data Board `json:"data"`
+}
+
+type RestoreDashboardVersionRequest struct {
+	Version int `json:"version"`
+}
+
+// SearchHit is one result of the search API. It only carries the summary
+// of a dashboard or folder, use GetDashboardForHit to fetch the full Board.
+type SearchHit struct {
+	ID          uint     `json:"id"`
+	UID         string   `json:"uid"`
+	Title       string   `json:"title"`
+	URI         string   `json:"uri"`
+	URL         string   `json:"url"`
+	Slug        string   `json:"slug"`
+	Type        string   `json:"type"`
+	Tags        []string `json:"tags"`
+	IsStarred   bool     `json:"isStarred"`
+	FolderID    uint     `json:"folderId,omitempty"`
+	FolderUID   string   `json:"folderUid,omitempty"`
+	FolderTitle string   `json:"folderTitle,omitempty"`
+	FolderURL   string   `json:"folderUrl,omitempty"`
+	SortMeta    int64    `json:"sortMeta,omitempty"`
+}
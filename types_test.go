@@ -0,0 +1,200 @@
+package gografana
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var exportedDashboards = []string{
+	"dashboard_panels.json",
+	"dashboard_rows.json",
+}
+
+// normalizeJSON 去掉缩进以及key顺序上的差异
+func normalizeJSON(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	normalized, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return normalized
+}
+
+func loadExportedDashboard(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func assertSameJSON(t *testing.T, expected, actual []byte) {
+	t.Helper()
+	want, got := normalizeJSON(t, expected), normalizeJSON(t, actual)
+	if !bytes.Equal(want, got) {
+		t.Errorf("dashboard changed after round-trip\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestBoardRoundTrip(t *testing.T) {
+	for _, name := range exportedDashboards {
+		t.Run(name, func(t *testing.T) {
+			data := loadExportedDashboard(t, name)
+			var board Board
+			if err := json.Unmarshal(data, &board); err != nil {
+				t.Fatal(err)
+			}
+			out, err := json.Marshal(&board)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSameJSON(t, data, out)
+		})
+	}
+}
+
+func TestCreateDashboardRequestRoundTrip(t *testing.T) {
+	for _, name := range exportedDashboards {
+		t.Run(name, func(t *testing.T) {
+			data := loadExportedDashboard(t, name)
+			var rsp GetDashboardByUIdResponse
+			if err := json.Unmarshal([]byte(`{"dashboard":`+string(data)+`}`), &rsp); err != nil {
+				t.Fatal(err)
+			}
+			//Board在请求体中以值的形式出现，同样需要保留未建模的字段
+			out, err := json.Marshal(CreateDashboardRequest{Board: rsp.Dashboard, Message: "round-trip"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var req struct {
+				Dashboard json.RawMessage `json:"dashboard"`
+			}
+			if err = json.Unmarshal(out, &req); err != nil {
+				t.Fatal(err)
+			}
+			assertSameJSON(t, data, req.Dashboard)
+		})
+	}
+}
+
+func TestBoardRoundTripWithChanges(t *testing.T) {
+	var board Board
+	if err := json.Unmarshal(loadExportedDashboard(t, "dashboard_panels.json"), &board); err != nil {
+		t.Fatal(err)
+	}
+	board.Title = "Service overview (copy)"
+	delete(board.Panels[0].Options, "tooltip")
+	board.Panels[1].Transparent = true
+	out, err := json.Marshal(&board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved map[string]interface{}
+	if err = json.Unmarshal(out, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved["title"] != "Service overview (copy)" {
+		t.Errorf("title not updated: %v", saved["title"])
+	}
+	if _, ok := saved["templating"]; !ok {
+		t.Error("templating was dropped")
+	}
+	panels := saved["panels"].([]interface{})
+	if _, ok := panels[0].(map[string]interface{})["options"].(map[string]interface{})["tooltip"]; ok {
+		t.Error("removed panel option was restored")
+	}
+	if panels[1].(map[string]interface{})["transparent"] != true {
+		t.Error("transparent not set")
+	}
+	if panels[1].(map[string]interface{})["timeFrom"] != "1h" {
+		t.Error("unmodeled panel field was dropped")
+	}
+
+	//Panel_5_0会把嵌套结构中未建模的字段合并回去，但不能恢复调用方删除或者替换的内容
+	var rows Board
+	if err = json.Unmarshal(loadExportedDashboard(t, "dashboard_rows.json"), &rows); err != nil {
+		t.Fatal(err)
+	}
+	graph := &rows.Rows[0].Panels[0]
+	graph.AliasColors = map[string]string{}
+	graph.SeriesOverrides = []interface{}{map[string]interface{}{"alias": "y"}}
+	graph.Targets[0].Expr = "up"
+	graph.Legend.Show = false
+	graph.Yaxes[1].Show = true
+	graph.Links = nil
+	out, err = json.Marshal(&rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var savedRows struct {
+		Rows []struct {
+			Panels []map[string]interface{} `json:"panels"`
+		} `json:"rows"`
+	}
+	if err = json.Unmarshal(out, &savedRows); err != nil {
+		t.Fatal(err)
+	}
+	savedGraph := savedRows.Rows[0].Panels[0]
+	if overrides := savedGraph["seriesOverrides"].([]interface{}); !reflect.DeepEqual(overrides, []interface{}{map[string]interface{}{"alias": "y"}}) {
+		t.Errorf("replaced series override was merged with the old one: %v", overrides)
+	}
+	target := savedGraph["targets"].([]interface{})[0].(map[string]interface{})
+	if target["expr"] != "up" {
+		t.Errorf("target expr not updated: %v", target)
+	}
+	if _, ok := target["step"]; ok {
+		t.Errorf("modified target got the old target's fields back: %v", target)
+	}
+	legend := savedGraph["legend"].(map[string]interface{})
+	if legend["show"] != false || legend["rightSide"] != true {
+		t.Errorf("legend not merged correctly: %v", legend)
+	}
+	yaxes := savedGraph["yaxes"].([]interface{})
+	if yaxes[0].(map[string]interface{})["decimals"] != 1.0 {
+		t.Errorf("unmodified yaxis lost its unmodeled fields: %v", yaxes[0])
+	}
+	if yaxes[1].(map[string]interface{})["show"] != true {
+		t.Errorf("yaxis not updated: %v", yaxes[1])
+	}
+	if links := savedGraph["links"]; links != nil {
+		t.Errorf("cleared links were restored: %v", links)
+	}
+
+	var panel Panel_5_0
+	if err = json.Unmarshal([]byte(`{"aliasColors":{"a":"red"},"seriesOverrides":[{"alias":"x","color":"red"}],"type":"graph"}`), &panel); err != nil {
+		t.Fatal(err)
+	}
+	delete(panel.AliasColors, "a")
+	panel.SeriesOverrides = []interface{}{map[string]interface{}{"alias": "y"}}
+	out, err = json.Marshal(&panel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameJSON(t, []byte(`{"aliasColors":{},"seriesOverrides":[{"alias":"y"}],"type":"graph"}`), out)
+}
+
+func TestBoardExtraForcesZeroValue(t *testing.T) {
+	var board Board
+	if err := json.Unmarshal([]byte(`{"uid":"a","title":"t"}`), &board); err != nil {
+		t.Fatal(err)
+	}
+	board.Editable = false
+	out, _ := json.Marshal(&board)
+	if bytes.Contains(out, []byte(`"editable"`)) {
+		t.Fatalf("zero value absent from the original JSON should be omitted: %s", out)
+	}
+	board.Extra = map[string]json.RawMessage{"editable": json.RawMessage("false")}
+	out, _ = json.Marshal(&board)
+	if !bytes.Contains(out, []byte(`"editable":false`)) {
+		t.Fatalf("editable not forced through Extra: %s", out)
+	}
+}
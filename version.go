@@ -0,0 +1,122 @@
+package gografana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+type healthResponse struct {
+	Commit   string `json:"commit"`
+	Database string `json:"database"`
+	Version  string `json:"version"`
+}
+
+type frontendSettingsResponse struct {
+	BuildInfo struct {
+		Version string `json:"version"`
+		Commit  string `json:"commit"`
+	} `json:"buildInfo"`
+}
+
+// GetGrafanaVersion 获取远程Grafana服务端的版本号，例如"8.5.3"
+func (gc *GrafanaClient_5_0) GetGrafanaVersion() (string, error) {
+	return gc.GetGrafanaVersionCtx(context.Background())
+}
+
+// GetGrafanaVersionCtx 优先通过/api/health获取版本号，老版本的Grafana在health中不返回版本号时
+// 再通过/api/frontend/settings中的buildInfo.version获取。
+func (gc *GrafanaClient_5_0) GetGrafanaVersionCtx(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/health", gc.basicAddress), nil)
+	if err != nil {
+		return "", err
+	}
+	bodyData, healthErr := gc.getHTTPResponse(req, "GetHealth(api/health)")
+	if healthErr == nil {
+		var rsp healthResponse
+		if err = json.Unmarshal(bodyData, &rsp); err == nil && rsp.Version != "" {
+			return rsp.Version, nil
+		}
+	}
+	req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/frontend/settings", gc.basicAddress), nil)
+	if err != nil {
+		return "", err
+	}
+	bodyData, err = gc.getHTTPResponse(req, "GetFrontendSettings(api/frontend/settings)")
+	if err != nil {
+		if healthErr != nil {
+			return "", fmt.Errorf("Detect Grafana version failed, health error: %s, frontend settings error: %s", healthErr.Error(), err.Error())
+		}
+		return "", err
+	}
+	var rsp frontendSettingsResponse
+	err = json.Unmarshal(bodyData, &rsp)
+	if err != nil {
+		return "", fmt.Errorf("Unmarshal response body failed while calling to API GetFrontendSettings(api/frontend/settings), error: %s", err.Error())
+	}
+	if rsp.BuildInfo.Version == "" {
+		return "", fmt.Errorf("Detect Grafana version failed, neither api/health nor api/frontend/settings returned a version")
+	}
+	return rsp.BuildInfo.Version, nil
+}
+
+// versionCache 缓存远程Grafana的版本号，避免每次调用都重新探测。所有字段都由mu保护，但探测请求不在锁内进行
+type versionCache struct {
+	mu       sync.Mutex
+	detected bool
+	//探测失败时known为false
+	known   bool
+	version semVersion
+	//版本号未知时，基于UID的API返回404而基于ID的API成功，说明服务端不支持基于UID的Dashboard API
+	noUIDDashboardAPI bool
+	//不为nil时表示有一个探测正在进行，探测结束时被close
+	detecting chan struct{}
+}
+
+// serverVersion 返回远程Grafana的版本号，第一次调用时探测并缓存结果，同一时间只有一个探测请求，
+// 其余调用等待探测结束或者各自的ctx结束。探测失败时返回false(因为ctx被取消而失败的不会被缓存，之后的调用会重新探测)。
+func (gc *GrafanaClient_5_0) serverVersion(ctx context.Context) (semVersion, bool) {
+	cache := gc.version
+	for {
+		cache.mu.Lock()
+		if cache.detected {
+			v, known := cache.version, cache.known
+			cache.mu.Unlock()
+			return v, known
+		}
+		if cache.detecting == nil {
+			done := make(chan struct{})
+			cache.detecting = done
+			cache.mu.Unlock()
+			return gc.detectServerVersion(ctx, done)
+		}
+		detecting := cache.detecting
+		cache.mu.Unlock()
+		select {
+		case <-detecting:
+		case <-ctx.Done():
+			return semVersion{}, false
+		}
+	}
+}
+
+func (gc *GrafanaClient_5_0) detectServerVersion(ctx context.Context, done chan struct{}) (semVersion, bool) {
+	cache := gc.version
+	raw, err := gc.GetGrafanaVersionCtx(ctx)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.detecting = nil
+	close(done)
+	if err != nil && ctx.Err() != nil {
+		return semVersion{}, false
+	}
+	cache.detected = true
+	if err == nil {
+		if v, perr := parseVersion(raw); perr == nil {
+			cache.version, cache.known = v, true
+		}
+	}
+	return cache.version, cache.known
+}